@@ -7,6 +7,8 @@ import (
 
 	"time"
 
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
 	"github.com/art-vbst/art-backend/internal/platform/config"
 	"github.com/art-vbst/art-backend/internal/platform/db/pooler"
 	"github.com/art-vbst/art-backend/internal/platform/db/store"
@@ -24,12 +26,23 @@ func main() {
 	defer pool.Close()
 	store := store.New(pool)
 
+	scheme, err := repo.New(store).GetCatalogNumbering(ctx)
+	if err != nil {
+		log.Fatalf("failed to read catalog numbering: %v", err)
+	}
+	if scheme != domain.NumberingScheme(env.CatalogNumbering) {
+		log.Fatalf("CATALOG_NUMBERING is %s but the database enforces %q numbering, run catalognumbering -scheme %s", env.CatalogNumbering, scheme, env.CatalogNumbering)
+	}
+
 	provider := storage.NewProvider(env)
 	defer provider.Close()
 
 	mailer := mailer.New(env)
 
-	r := router.New(store, provider, env, mailer).CreateRouter()
+	r, err := router.New(store, provider, env, mailer).CreateRouter()
+	if err != nil {
+		log.Fatalf("failed to create router: %v", err)
+	}
 
 	if config.IsDebug() {
 		log.Printf("[WARNING] debug mode enabled")
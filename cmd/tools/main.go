@@ -9,6 +9,7 @@ import (
 	"github.com/art-vbst/art-backend/internal/platform/config"
 	"github.com/art-vbst/art-backend/internal/platform/db/pooler"
 	"github.com/art-vbst/art-backend/internal/platform/db/store"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
 	"github.com/art-vbst/art-backend/internal/platform/tools"
 )
 
@@ -22,7 +23,7 @@ func main() {
 
 	if len(os.Args[1:]) == 0 {
 		fmt.Println("A command must be specified")
-		fmt.Println("Available commands: createuser")
+		fmt.Println("Available commands: createuser, catalogreport, catalognumbering, reconcilestorage, regeneratederivatives, migratestorage")
 		return
 	}
 
@@ -31,5 +32,34 @@ func main() {
 		if err := tools.CreateUser(ctx, store); err != nil {
 			log.Fatal(err)
 		}
+	case "catalogreport":
+		if err := tools.CatalogReport(ctx, store, config); err != nil {
+			log.Fatal(err)
+		}
+	case "catalognumbering":
+		if err := tools.CatalogNumbering(ctx, store, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "reconcilestorage":
+		provider := storage.NewProvider(config)
+		defer provider.Close()
+
+		if err := tools.ReconcileStorage(ctx, store, provider, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "regeneratederivatives":
+		provider := storage.NewProvider(config)
+		defer provider.Close()
+
+		if err := tools.RegenerateDerivatives(ctx, store, provider, config, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "migratestorage":
+		provider := storage.NewProvider(config)
+		defer provider.Close()
+
+		if err := tools.MigrateStorage(ctx, store, provider, config, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
 	}
 }
@@ -40,6 +40,13 @@ const (
 	ArtworkCategoryOther       ArtworkCategory = "other"
 )
 
+const SortOrderGap = 1000
+
+type ArtworkSortOrder struct {
+	ID        uuid.UUID `json:"id"`
+	SortOrder int32     `json:"sort_order"`
+}
+
 type Artwork struct {
 	ID             uuid.UUID       `json:"id"`
 	Title          string          `json:"title"`
@@ -47,6 +54,9 @@ type Artwork struct {
 	PaintingYear   *int32          `json:"painting_year"`
 	WidthInches    float64         `json:"width_inches"`
 	HeightInches   float64         `json:"height_inches"`
+	DepthInches    *float64        `json:"depth_inches"`
+	WeightPounds   *float64        `json:"weight_pounds"`
+	Measurements   *Measurements   `json:"measurements"`
 	PriceCents     int32           `json:"price_cents"`
 	Description    string          `json:"description"`
 	Paper          *bool           `json:"paper"`
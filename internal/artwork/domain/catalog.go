@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type NumberingScheme string
+
+const (
+	NumberingSchemeGlobal NumberingScheme = "global"
+	NumberingSchemeYear   NumberingScheme = "year"
+	NumberingSchemeMedium NumberingScheme = "medium"
+)
+
+func (s NumberingScheme) Scope(year *int32, medium ArtworkMedium) string {
+	switch s {
+	case NumberingSchemeYear:
+		if year == nil {
+			return "year:unknown"
+		}
+		return fmt.Sprintf("year:%d", *year)
+	case NumberingSchemeMedium:
+		return fmt.Sprintf("medium:%s", medium)
+	default:
+		return string(NumberingSchemeGlobal)
+	}
+}
+
+type CatalogEntry struct {
+	ID             uuid.UUID     `json:"id"`
+	Title          string        `json:"title"`
+	PaintingNumber *int32        `json:"painting_number"`
+	PaintingYear   *int32        `json:"painting_year"`
+	Medium         ArtworkMedium `json:"medium"`
+}
+
+type CatalogReport struct {
+	Scheme NumberingScheme      `json:"scheme"`
+	Scopes []CatalogScopeReport `json:"scopes"`
+}
+
+type CatalogScopeReport struct {
+	Scope      string             `json:"scope"`
+	Numbered   int                `json:"numbered"`
+	Highest    int32              `json:"highest"`
+	Duplicates []CatalogDuplicate `json:"duplicates"`
+	Gaps       []CatalogGap       `json:"gaps"`
+	Unnumbered []CatalogEntry     `json:"unnumbered"`
+}
+
+type CatalogDuplicate struct {
+	PaintingNumber int32          `json:"painting_number"`
+	Entries        []CatalogEntry `json:"entries"`
+}
+
+type CatalogGap struct {
+	From int32 `json:"from"`
+	To   int32 `json:"to"`
+}
+
+func NextPaintingNumber(entries []CatalogEntry, scheme NumberingScheme, year *int32, medium ArtworkMedium) int32 {
+	scope := scheme.Scope(year, medium)
+
+	var highest int32
+	for _, entry := range entries {
+		if entry.PaintingNumber == nil || scheme.Scope(entry.PaintingYear, entry.Medium) != scope {
+			continue
+		}
+		highest = max(highest, *entry.PaintingNumber)
+	}
+
+	return highest + 1
+}
+
+func FindPaintingNumberConflict(entries []CatalogEntry, scheme NumberingScheme, id uuid.UUID, number int32, year *int32, medium ArtworkMedium) *CatalogEntry {
+	scope := scheme.Scope(year, medium)
+
+	for i, entry := range entries {
+		if entry.ID == id || entry.PaintingNumber == nil || *entry.PaintingNumber != number {
+			continue
+		}
+		if scheme.Scope(entry.PaintingYear, entry.Medium) == scope {
+			return &entries[i]
+		}
+	}
+
+	return nil
+}
+
+func BuildCatalogReport(entries []CatalogEntry, scheme NumberingScheme) *CatalogReport {
+	scopes := map[string]*CatalogScopeReport{}
+	numbers := map[string]map[int32][]CatalogEntry{}
+	order := []string{}
+
+	for _, entry := range entries {
+		scope := scheme.Scope(entry.PaintingYear, entry.Medium)
+		report, ok := scopes[scope]
+		if !ok {
+			report = &CatalogScopeReport{
+				Scope:      scope,
+				Duplicates: []CatalogDuplicate{},
+				Gaps:       []CatalogGap{},
+				Unnumbered: []CatalogEntry{},
+			}
+			scopes[scope] = report
+			numbers[scope] = map[int32][]CatalogEntry{}
+			order = append(order, scope)
+		}
+
+		if entry.PaintingNumber == nil {
+			report.Unnumbered = append(report.Unnumbered, entry)
+			continue
+		}
+
+		number := *entry.PaintingNumber
+		report.Numbered++
+		report.Highest = max(report.Highest, number)
+		numbers[scope][number] = append(numbers[scope][number], entry)
+	}
+
+	slices.Sort(order)
+
+	catalog := &CatalogReport{Scheme: scheme, Scopes: make([]CatalogScopeReport, 0, len(order))}
+	for _, scope := range order {
+		report := scopes[scope]
+		used := numbers[scope]
+
+		keys := slices.Sorted(maps.Keys(used))
+
+		var previous int32
+		for _, n := range keys {
+			if matches := used[n]; len(matches) > 1 {
+				report.Duplicates = append(report.Duplicates, CatalogDuplicate{PaintingNumber: n, Entries: matches})
+			}
+			if n < 1 {
+				continue
+			}
+			if n > previous+1 {
+				report.Gaps = append(report.Gaps, CatalogGap{From: previous + 1, To: n - 1})
+			}
+			previous = n
+		}
+
+		catalog.Scopes = append(catalog.Scopes, *report)
+	}
+
+	return catalog
+}
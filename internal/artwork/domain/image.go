@@ -7,12 +7,91 @@ import (
 )
 
 type Image struct {
-	ID          uuid.UUID `json:"id"`
-	ArtworkID   uuid.UUID `json:"artwork_id"`
-	IsMainImage bool      `json:"is_main_image"`
-	ImageURL    string    `json:"image_url"`
-	ObjectName  string    `json:"object_name"`
-	ImageWidth  *int32    `json:"image_width"`
-	ImageHeight *int32    `json:"image_height"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            uuid.UUID      `json:"id"`
+	ArtworkID     uuid.UUID      `json:"artwork_id"`
+	IsMainImage   bool           `json:"is_main_image"`
+	ImageURL      string         `json:"image_url"`
+	ObjectName    string         `json:"object_name"`
+	ImageWidth    *int32         `json:"image_width"`
+	ImageHeight   *int32         `json:"image_height"`
+	Variants      []ImageVariant `json:"variants"`
+	BlurHash      *string        `json:"blurhash"`
+	DominantColor *string        `json:"dominant_color"`
+	Palette       []PaletteColor `json:"palette"`
+	AltText       *string        `json:"alt_text"`
+	Caption       *string        `json:"caption"`
+	Credit        *string        `json:"credit"`
+	Position      int32          `json:"position"`
+	PHash         *int64         `json:"-"`
+	Duplicates    []SimilarImage `json:"duplicates,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type PaletteColor struct {
+	Color  string  `json:"color"`
+	Weight float64 `json:"weight"`
+}
+
+type SimilarImage struct {
+	ImageID      uuid.UUID `json:"image_id"`
+	ArtworkID    uuid.UUID `json:"artwork_id"`
+	ArtworkTitle string    `json:"artwork_title"`
+	ImageURL     string    `json:"image_url"`
+	ObjectName   string    `json:"-"`
+	Distance     int32     `json:"distance"`
+}
+
+type ImageVariant struct {
+	Name        string `json:"name"`
+	Format      string `json:"format"`
+	ContentType string `json:"content_type"`
+	Width       int32  `json:"width"`
+	Height      int32  `json:"height"`
+	ObjectName  string `json:"object_name"`
+	URL         string `json:"url"`
+}
+
+func (i *Image) LargestVariant() *ImageVariant {
+	var largest *ImageVariant
+	for j := range i.Variants {
+		if largest == nil || i.Variants[j].Width > largest.Width {
+			largest = &i.Variants[j]
+		}
+	}
+	return largest
+}
+
+type ImageUploadResult struct {
+	FileName  string     `json:"file_name"`
+	ArtworkID *uuid.UUID `json:"artwork_id"`
+	Image     *Image     `json:"image"`
+	Error     *string    `json:"error"`
+}
+
+type ImageUploadReport struct {
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []ImageUploadResult `json:"results"`
+}
+
+func NewImageUploadReport() *ImageUploadReport {
+	return &ImageUploadReport{Results: []ImageUploadResult{}}
+}
+
+func (r *ImageUploadReport) AddSuccess(fileName string, image *Image) {
+	r.Succeeded++
+	r.Results = append(r.Results, ImageUploadResult{FileName: fileName, ArtworkID: &image.ArtworkID, Image: image})
+}
+
+func (r *ImageUploadReport) AddFailure(fileName string, artworkID *uuid.UUID, message string) {
+	r.Failed++
+	r.Results = append(r.Results, ImageUploadResult{FileName: fileName, ArtworkID: artworkID, Error: &message})
+}
+
+type DirectUpload struct {
+	UploadURL   string            `json:"upload_url"`
+	Method      string            `json:"method"`
+	Headers     map[string]string `json:"headers"`
+	UploadToken string            `json:"upload_token"`
+	ExpiresAt   time.Time         `json:"expires_at"`
 }
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type MeasurementSystem string
+
+const (
+	MeasurementSystemImperial MeasurementSystem = "imperial"
+	MeasurementSystemMetric   MeasurementSystem = "metric"
+)
+
+const (
+	centimetersPerInch = 2.54
+	kilogramsPerPound  = 0.45359237
+)
+
+type Measurements struct {
+	System     MeasurementSystem `json:"system"`
+	Width      float64           `json:"width"`
+	Height     float64           `json:"height"`
+	Depth      *float64          `json:"depth"`
+	Weight     *float64          `json:"weight"`
+	LengthUnit string            `json:"length_unit"`
+	WeightUnit string            `json:"weight_unit"`
+	Display    string            `json:"display"`
+}
+
+func NewMeasurements(artwork *Artwork, system MeasurementSystem) *Measurements {
+	lengthFactor, weightFactor := 1.0, 1.0
+	m := &Measurements{System: MeasurementSystemImperial, LengthUnit: "in", WeightUnit: "lb"}
+
+	if system == MeasurementSystemMetric {
+		lengthFactor, weightFactor = centimetersPerInch, kilogramsPerPound
+		m.System, m.LengthUnit, m.WeightUnit = MeasurementSystemMetric, "cm", "kg"
+	}
+
+	m.Width = roundMeasurement(artwork.WidthInches * lengthFactor)
+	m.Height = roundMeasurement(artwork.HeightInches * lengthFactor)
+
+	if artwork.DepthInches != nil {
+		depth := roundMeasurement(*artwork.DepthInches * lengthFactor)
+		m.Depth = &depth
+	}
+
+	if artwork.WeightPounds != nil {
+		weight := roundMeasurement(*artwork.WeightPounds * weightFactor)
+		m.Weight = &weight
+	}
+
+	m.Display = m.format()
+	return m
+}
+
+func (m *Measurements) format() string {
+	sides := []string{formatMeasurement(m.Height), formatMeasurement(m.Width)}
+	if m.Depth != nil {
+		sides = append(sides, formatMeasurement(*m.Depth))
+	}
+
+	display := fmt.Sprintf("%s %s", strings.Join(sides, " × "), m.LengthUnit)
+	if m.Weight != nil {
+		display += fmt.Sprintf(", %s %s", formatMeasurement(*m.Weight), m.WeightUnit)
+	}
+
+	return display
+}
+
+func roundMeasurement(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+func formatMeasurement(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
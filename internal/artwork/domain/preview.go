@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPreviewExpiration = 7 * 24 * time.Hour
+	MaxPreviewExpiration     = 90 * 24 * time.Hour
+)
+
+type PreviewLink struct {
+	ID           uuid.UUID   `json:"id"`
+	ArtworkIDs   []uuid.UUID `json:"artwork_ids"`
+	Label        *string     `json:"label"`
+	ExpiresAt    time.Time   `json:"expires_at"`
+	Revoked      bool        `json:"revoked"`
+	ViewCount    int32       `json:"view_count"`
+	LastViewedAt *time.Time  `json:"last_viewed_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+type PreviewLinkWithToken struct {
+	PreviewLink
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+type Preview struct {
+	Label     *string   `json:"label"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Artworks  []Artwork `json:"artworks"`
+}
@@ -5,9 +5,12 @@ import "github.com/google/uuid"
 type ArtworkPayload struct {
 	Title          string          `json:"title"`
 	PaintingNumber *int32          `json:"painting_number"`
+	AutoNumber     bool            `json:"auto_number"`
 	PaintingYear   *int32          `json:"painting_year"`
 	WidthInches    float64         `json:"width_inches"`
 	HeightInches   float64         `json:"height_inches"`
+	DepthInches    *float64        `json:"depth_inches"`
+	WeightPounds   *float64        `json:"weight_pounds"`
 	PriceCents     int             `json:"price_cents"`
 	Description    string          `json:"description"`
 	Paper          bool            `json:"paper"`
@@ -17,11 +20,68 @@ type ArtworkPayload struct {
 	Category       ArtworkCategory `json:"category"`
 }
 
+type CatalogExportFilter struct {
+	Statuses   []ArtworkStatus
+	Categories []ArtworkCategory
+	YearFrom   *int32
+	YearTo     *int32
+}
+
+type ReorderPayload struct {
+	IDs  []uuid.UUID  `json:"ids"`
+	Move *MovePayload `json:"move"`
+}
+
+type MovePayload struct {
+	ID     uuid.UUID  `json:"id"`
+	Before *uuid.UUID `json:"before"`
+	After  *uuid.UUID `json:"after"`
+}
+
+type PreviewPayload struct {
+	ArtworkIDs     []uuid.UUID `json:"artwork_ids"`
+	Label          *string     `json:"label"`
+	ExpiresInHours int         `json:"expires_in_hours"`
+}
+
 type CreateImagePayload struct {
-	ArtworkID   uuid.UUID
-	ObjectName  string
-	ImageURL    string
-	IsMainImage bool
-	ImageWidth  *int32
-	ImageHeight *int32
+	ArtworkID     uuid.UUID
+	ObjectName    string
+	ImageURL      string
+	IsMainImage   bool
+	ImageWidth    *int32
+	ImageHeight   *int32
+	Variants      []ImageVariant
+	BlurHash      *string
+	DominantColor *string
+	Palette       []PaletteColor
+	AltText       *string
+	Caption       *string
+	Credit        *string
+	PHash         *int64
+}
+
+type UpdateImagePayload struct {
+	IsMainImage *bool
+	AltText     *string
+	Caption     *string
+	Credit      *string
+}
+
+type ImageOrderPayload struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+type DirectUploadPayload struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type FinalizeUploadPayload struct {
+	UploadToken string  `json:"upload_token"`
+	IsMainImage bool    `json:"is_main_image"`
+	AltText     *string `json:"alt_text"`
+	Caption     *string `json:"caption"`
+	Credit      *string `json:"credit"`
 }
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/db/generated"
+)
+
+const paintingNumberIndexPrefix = "idx_artworks_painting_number_"
+
+// GetCatalogNumbering reports which scheme the painting number unique index
+// enforces, or an empty scheme when there is none.
+func (p *Postgres) GetCatalogNumbering(ctx context.Context) (domain.NumberingScheme, error) {
+	indexes, err := p.db.Queries().ListPaintingNumberIndexes(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(indexes) != 1 {
+		return "", nil
+	}
+
+	return domain.NumberingScheme(strings.TrimPrefix(indexes[0], paintingNumberIndexPrefix)), nil
+}
+
+// SwitchCatalogNumbering replaces the painting number unique index with the one
+// for the given scheme. It fails while the catalog has duplicates in that scheme.
+func (p *Postgres) SwitchCatalogNumbering(ctx context.Context, scheme domain.NumberingScheme) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		if err := q.LockCatalogNumbers(ctx); err != nil {
+			return err
+		}
+
+		if err := q.DropPaintingNumberUniqueIndexes(ctx); err != nil {
+			return err
+		}
+
+		switch scheme {
+		case domain.NumberingSchemeYear:
+			return q.CreateYearPaintingNumberUniqueIndex(ctx)
+		case domain.NumberingSchemeMedium:
+			return q.CreateMediumPaintingNumberUniqueIndex(ctx)
+		default:
+			return q.CreateGlobalPaintingNumberUniqueIndex(ctx)
+		}
+	})
+}
@@ -10,10 +10,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-func (p *Postgres) CreateArtwork(ctx context.Context, body *domain.ArtworkPayload) (*domain.Artwork, error) {
+func (p *Postgres) CreateArtwork(
+	ctx context.Context,
+	body *domain.ArtworkPayload,
+	callback func(entries []domain.CatalogEntry) error,
+) (*domain.Artwork, error) {
 	var created *domain.Artwork
 
 	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		if err := p.checkCatalogNumbers(ctx, q, callback); err != nil {
+			return err
+		}
+
 		params, err := p.toCreateArtworkParams(body)
 		if err != nil {
 			return err
@@ -43,7 +51,16 @@ func (p *Postgres) CreateImage(ctx context.Context, data *domain.CreateImagePayl
 	var image *domain.Image
 
 	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
-		params := p.toCreateImageParams(data)
+		params, err := p.toCreateImageParams(data)
+		if err != nil {
+			return err
+		}
+
+		if data.IsMainImage {
+			if err := q.ClearMainImage(ctx, params.ArtworkID); err != nil {
+				return err
+			}
+		}
 
 		row, err := q.CreateImage(ctx, *params)
 		if err != nil {
@@ -73,12 +90,24 @@ func (p *Postgres) toCreateArtworkParams(body *domain.ArtworkPayload) (*generate
 		return nil, err
 	}
 
+	depth, err := utils.NumericFromFloatPtr(body.DepthInches)
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := utils.NumericFromFloatPtr(body.WeightPounds)
+	if err != nil {
+		return nil, err
+	}
+
 	params := generated.CreateArtworkParams{
 		Title:          body.Title,
 		PaintingNumber: body.PaintingNumber,
 		PaintingYear:   body.PaintingYear,
 		WidthInches:    width,
 		HeightInches:   height,
+		DepthInches:    depth,
+		WeightPounds:   weight,
 		PriceCents:     int32(body.PriceCents),
 		Description:    &body.Description,
 		Paper:          &body.Paper,
@@ -90,13 +119,31 @@ func (p *Postgres) toCreateArtworkParams(body *domain.ArtworkPayload) (*generate
 	return &params, nil
 }
 
-func (p *Postgres) toCreateImageParams(data *domain.CreateImagePayload) *generated.CreateImageParams {
-	return &generated.CreateImageParams{
-		ArtworkID:   pgtype.UUID{Bytes: data.ArtworkID, Valid: true},
-		ObjectName:  data.ObjectName,
-		ImageUrl:    data.ImageURL,
-		IsMainImage: data.IsMainImage,
-		ImageWidth:  data.ImageWidth,
-		ImageHeight: data.ImageHeight,
+func (p *Postgres) toCreateImageParams(data *domain.CreateImagePayload) (*generated.CreateImageParams, error) {
+	variants, err := toImageVariantsJSON(data.Variants)
+	if err != nil {
+		return nil, err
 	}
+
+	palette, err := toPaletteJSON(data.Palette)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generated.CreateImageParams{
+		ArtworkID:     pgtype.UUID{Bytes: data.ArtworkID, Valid: true},
+		ObjectName:    data.ObjectName,
+		ImageUrl:      data.ImageURL,
+		IsMainImage:   data.IsMainImage,
+		ImageWidth:    data.ImageWidth,
+		ImageHeight:   data.ImageHeight,
+		Variants:      variants,
+		Blurhash:      data.BlurHash,
+		DominantColor: data.DominantColor,
+		AltText:       data.AltText,
+		Caption:       data.Caption,
+		Credit:        data.Credit,
+		Phash:         data.PHash,
+		Palette:       palette,
+	}, nil
 }
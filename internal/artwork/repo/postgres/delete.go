@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/platform/db/generated"
 	"github.com/google/uuid"
 )
@@ -13,8 +14,31 @@ func (p *Postgres) DeleteArtwork(ctx context.Context, id uuid.UUID) error {
 	})
 }
 
-func (p *Postgres) DeleteImage(ctx context.Context, id uuid.UUID) error {
-	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
-		return q.DeleteImage(ctx, id)
+// DeleteImage returns the deleted image when it held the last reference to
+// its files, and nil when they are still in use.
+func (p *Postgres) DeleteImage(ctx context.Context, id uuid.UUID) (*domain.Image, error) {
+	var released *domain.Image
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		row, err := q.DeleteImage(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		image := toDomainImage(&row)
+		last, err := releaseObject(ctx, q, image.ObjectName)
+		if err != nil {
+			return err
+		}
+		if last {
+			released = image
+		}
+		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return released, nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/platform/db/generated"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
 	"github.com/google/uuid"
 )
 
@@ -31,6 +32,59 @@ func (p *Postgres) GetImageDetail(ctx context.Context, id uuid.UUID) (*domain.Im
 	return toDomainImage(&image), nil
 }
 
+func (p *Postgres) ListImages(ctx context.Context) ([]domain.Image, error) {
+	rows, err := p.db.Queries().ListImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]domain.Image, len(rows))
+	for i, row := range rows {
+		images[i] = *toDomainImage(&row)
+	}
+
+	return images, nil
+}
+
+func (p *Postgres) ListArtworksWithMainImage(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := p.db.Queries().ListArtworksWithMainImage(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	artworkIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		artworkIDs[i] = uuid.UUID(row.Bytes)
+	}
+
+	return artworkIDs, nil
+}
+
+func (p *Postgres) ListSimilarImages(ctx context.Context, id uuid.UUID, hash int64, maxDistance int32) ([]domain.SimilarImage, error) {
+	rows, err := p.db.Queries().ListSimilarImages(ctx, generated.ListSimilarImagesParams{
+		Phash:       hash,
+		ExcludeID:   id,
+		MaxDistance: maxDistance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]domain.SimilarImage, len(rows))
+	for i, row := range rows {
+		images[i] = domain.SimilarImage{
+			ImageID:      row.ID,
+			ArtworkID:    uuid.UUID(row.ArtworkID.Bytes),
+			ArtworkTitle: row.ArtworkTitle,
+			ImageURL:     row.ImageUrl,
+			ObjectName:   row.ObjectName,
+			Distance:     row.Distance,
+		}
+	}
+
+	return images, nil
+}
+
 func (p *Postgres) toDetailDomainArtwork(rows []generated.GetArtworkWithImagesRow) (*domain.Artwork, error) {
 	if len(rows) == 0 {
 		return nil, ErrNoRows
@@ -48,6 +102,16 @@ func (p *Postgres) toDetailDomainArtwork(rows []generated.GetArtworkWithImagesRo
 		return nil, err
 	}
 
+	depthInches, err := utils.FloatPtrFromNumeric(artworkRow.DepthInches)
+	if err != nil {
+		return nil, err
+	}
+
+	weightPounds, err := utils.FloatPtrFromNumeric(artworkRow.WeightPounds)
+	if err != nil {
+		return nil, err
+	}
+
 	var description string
 	if artworkRow.Description != nil {
 		description = *artworkRow.Description
@@ -65,6 +129,8 @@ func (p *Postgres) toDetailDomainArtwork(rows []generated.GetArtworkWithImagesRo
 		PaintingYear:   artworkRow.PaintingYear,
 		WidthInches:    widthInches.Float64,
 		HeightInches:   heightInches.Float64,
+		DepthInches:    depthInches,
+		WeightPounds:   weightPounds,
 		PriceCents:     artworkRow.PriceCents,
 		Description:    description,
 		Paper:          artworkRow.Paper,
@@ -105,15 +171,28 @@ func (p *Postgres) toDetailDomainImage(rows []generated.GetArtworkWithImagesRow)
 			imageURL = *row.ImageUrl
 		}
 
+		var position int32
+		if row.Position != nil {
+			position = *row.Position
+		}
+
 		image := domain.Image{
-			ID:          imageID,
-			ArtworkID:   row.ID,
-			IsMainImage: isMainImage,
-			ObjectName:  objectName,
-			ImageURL:    imageURL,
-			ImageWidth:  row.ImageWidth,
-			ImageHeight: row.ImageHeight,
-			CreatedAt:   row.ImageCreatedAt.Time,
+			ID:            imageID,
+			ArtworkID:     row.ID,
+			IsMainImage:   isMainImage,
+			ObjectName:    objectName,
+			ImageURL:      imageURL,
+			ImageWidth:    row.ImageWidth,
+			ImageHeight:   row.ImageHeight,
+			Variants:      toDomainImageVariants(row.Variants),
+			Palette:       toDomainPalette(row.Palette),
+			BlurHash:      row.Blurhash,
+			DominantColor: row.DominantColor,
+			AltText:       row.AltText,
+			Caption:       row.Caption,
+			Credit:        row.Credit,
+			Position:      position,
+			CreatedAt:     row.ImageCreatedAt.Time,
 		}
 
 		images = append(images, image)
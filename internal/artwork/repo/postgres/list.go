@@ -6,6 +6,7 @@ import (
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/platform/db/generated"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
 	"github.com/google/uuid"
 )
 
@@ -30,24 +31,54 @@ func (p *Postgres) GetArtworkCheckoutData(ctx context.Context, ids []uuid.UUID)
 	return toDomainArtworkCheckoutListRow(artworks), nil
 }
 
+func (p *Postgres) ListCatalogEntries(ctx context.Context) ([]domain.CatalogEntry, error) {
+	rows, err := p.db.Queries().ListCatalogNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainCatalogEntries(rows), nil
+}
+
+func (p *Postgres) checkCatalogNumbers(ctx context.Context, q *generated.Queries, callback func(entries []domain.CatalogEntry) error) error {
+	if err := q.LockCatalogNumbers(ctx); err != nil {
+		return err
+	}
+
+	rows, err := q.ListCatalogNumbers(ctx)
+	if err != nil {
+		return err
+	}
+
+	return callback(toDomainCatalogEntries(rows))
+}
+
 func (p *Postgres) toDomainArtworkListRow(rows []generated.ListArtworksRow) []domain.Artwork {
 	artworks := []domain.Artwork{}
 
 	for _, row := range rows {
 		widthInches, _ := row.WidthInches.Float64Value()
 		heightInches, _ := row.HeightInches.Float64Value()
+		depthInches, _ := utils.FloatPtrFromNumeric(row.DepthInches)
+		weightPounds, _ := utils.FloatPtrFromNumeric(row.WeightPounds)
 
 		images := []domain.Image{}
 		if row.ImageID != uuid.Nil {
 			images = append(images, domain.Image{
-				ID:          row.ImageID,
-				ArtworkID:   row.ID,
-				IsMainImage: true,
-				ObjectName:  row.ObjectName,
-				ImageURL:    row.ImageUrl,
-				ImageWidth:  row.ImageWidth,
-				ImageHeight: row.ImageHeight,
-				CreatedAt:   row.ImageCreatedAt.Time,
+				ID:            row.ImageID,
+				ArtworkID:     row.ID,
+				IsMainImage:   true,
+				ObjectName:    row.ObjectName,
+				ImageURL:      row.ImageUrl,
+				ImageWidth:    row.ImageWidth,
+				ImageHeight:   row.ImageHeight,
+				Variants:      toDomainImageVariants(row.Variants),
+				Palette:       toDomainPalette(row.Palette),
+				BlurHash:      row.Blurhash,
+				DominantColor: row.DominantColor,
+				AltText:       row.AltText,
+				Caption:       row.Caption,
+				Credit:        row.Credit,
+				CreatedAt:     row.ImageCreatedAt.Time,
 			})
 		}
 
@@ -68,6 +99,8 @@ func (p *Postgres) toDomainArtworkListRow(rows []generated.ListArtworksRow) []do
 			PaintingYear:   row.PaintingYear,
 			WidthInches:    widthInches.Float64,
 			HeightInches:   heightInches.Float64,
+			DepthInches:    depthInches,
+			WeightPounds:   weightPounds,
 			PriceCents:     row.PriceCents,
 			Description:    description,
 			Paper:          row.Paper,
@@ -90,6 +123,11 @@ func toDomainArtworkCheckoutListRow(rows []generated.ListArtworkStripeDataRow) [
 	artworks := []domain.Artwork{}
 
 	for _, row := range rows {
+		widthInches, _ := row.WidthInches.Float64Value()
+		heightInches, _ := row.HeightInches.Float64Value()
+		depthInches, _ := utils.FloatPtrFromNumeric(row.DepthInches)
+		weightPounds, _ := utils.FloatPtrFromNumeric(row.WeightPounds)
+
 		images := []domain.Image{}
 
 		if row.ImageID != uuid.Nil {
@@ -97,15 +135,21 @@ func toDomainArtworkCheckoutListRow(rows []generated.ListArtworkStripeDataRow) [
 				ID:          row.ImageID,
 				ArtworkID:   row.ID,
 				IsMainImage: true,
+				ObjectName:  row.ObjectName,
 				ImageURL:    row.ImageUrl,
+				Variants:    toDomainImageVariants(row.Variants),
 			})
 		}
 
 		artwork := domain.Artwork{
-			ID:         row.ID,
-			Title:      row.Title,
-			PriceCents: row.PriceCents,
-			Images:     images,
+			ID:           row.ID,
+			Title:        row.Title,
+			WidthInches:  widthInches.Float64,
+			HeightInches: heightInches.Float64,
+			DepthInches:  depthInches,
+			WeightPounds: weightPounds,
+			PriceCents:   row.PriceCents,
+			Images:       images,
 		}
 
 		artworks = append(artworks, artwork)
@@ -113,3 +157,19 @@ func toDomainArtworkCheckoutListRow(rows []generated.ListArtworkStripeDataRow) [
 
 	return artworks
 }
+
+func toDomainCatalogEntries(rows []generated.ListCatalogNumbersRow) []domain.CatalogEntry {
+	entries := make([]domain.CatalogEntry, len(rows))
+
+	for i, row := range rows {
+		entries[i] = domain.CatalogEntry{
+			ID:             row.ID,
+			Title:          row.Title,
+			PaintingNumber: row.PaintingNumber,
+			PaintingYear:   row.PaintingYear,
+			Medium:         row.Medium,
+		}
+	}
+
+	return entries
+}
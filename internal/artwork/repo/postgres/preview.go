@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/db/generated"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (p *Postgres) GetArtworksByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Artwork, error) {
+	artworks := make([]domain.Artwork, 0, len(ids))
+
+	for _, id := range ids {
+		rows, err := p.db.Queries().GetArtworkWithImages(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		artwork, err := p.toDetailDomainArtwork(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		artworks = append(artworks, *artwork)
+	}
+
+	return artworks, nil
+}
+
+func (p *Postgres) CreatePreviewLink(ctx context.Context, ids []uuid.UUID, label *string, expiresAt time.Time) (*domain.PreviewLink, error) {
+	var link *domain.PreviewLink
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		row, err := q.CreatePreviewLink(ctx, generated.CreatePreviewLinkParams{
+			ArtworkIds: ids,
+			Label:      label,
+			ExpiresAt:  pgtype.Timestamp{Time: expiresAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		link = toDomainPreviewLink(&row)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (p *Postgres) ListPreviewLinks(ctx context.Context) ([]domain.PreviewLink, error) {
+	rows, err := p.db.Queries().ListPreviewLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]domain.PreviewLink, len(rows))
+	for i, row := range rows {
+		links[i] = *toDomainPreviewLink(&row)
+	}
+
+	return links, nil
+}
+
+func (p *Postgres) RecordPreviewLinkView(ctx context.Context, id uuid.UUID) (*domain.PreviewLink, error) {
+	var link *domain.PreviewLink
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		row, err := q.RecordPreviewLinkView(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		link = toDomainPreviewLink(&row)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (p *Postgres) RevokePreviewLink(ctx context.Context, id uuid.UUID) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		rows, err := q.RevokePreviewLink(ctx, id)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return pgx.ErrNoRows
+		}
+		return nil
+	})
+}
+
+func toDomainPreviewLink(row *generated.PreviewLink) *domain.PreviewLink {
+	var lastViewedAt *time.Time
+	if row.LastViewedAt.Valid {
+		lastViewedAt = &row.LastViewedAt.Time
+	}
+
+	return &domain.PreviewLink{
+		ID:           row.ID,
+		ArtworkIDs:   row.ArtworkIds,
+		Label:        row.Label,
+		ExpiresAt:    row.ExpiresAt.Time,
+		Revoked:      row.Revoked,
+		ViewCount:    row.ViewCount,
+		LastViewedAt: lastViewedAt,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
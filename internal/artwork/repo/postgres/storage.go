@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/db/generated"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (p *Postgres) UpdateImageURLs(
+	ctx context.Context,
+	id uuid.UUID,
+	rewrite func(current *domain.Image) (string, []domain.ImageVariant, error),
+) (*domain.Image, error) {
+	var image *domain.Image
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		current, err := q.GetImageForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		imageURL, variants, err := rewrite(toDomainImage(&current))
+		if err != nil {
+			return err
+		}
+
+		data, err := toImageVariantsJSON(variants)
+		if err != nil {
+			return err
+		}
+
+		row, err := q.UpdateImageURLs(ctx, generated.UpdateImageURLsParams{ID: id, ImageUrl: imageURL, Variants: data})
+		if err != nil {
+			return err
+		}
+
+		image = toDomainImage(&row)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (p *Postgres) AcquireObjectReference(ctx context.Context, objectName string) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		if err := q.LockObjectName(ctx, objectName); err != nil {
+			return err
+		}
+		return q.AcquireObjectReference(ctx, objectName)
+	})
+}
+
+func (p *Postgres) ReleaseObjectReference(ctx context.Context, objectName string) (bool, error) {
+	var released bool
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		var err error
+		released, err = releaseObject(ctx, q, objectName)
+		return err
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return released, nil
+}
+
+// releaseObject drops one reference to an object and reports whether that was
+// the last one. The files are left alone here; callers hand a released object
+// to DeleteReleasedObject once the transaction has committed.
+func releaseObject(ctx context.Context, q *generated.Queries, objectName string) (bool, error) {
+	if err := q.LockObjectName(ctx, objectName); err != nil {
+		return false, err
+	}
+
+	remaining, err := q.ReleaseObjectReference(ctx, objectName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("release reference to %s: %w", objectName, err)
+	}
+	if remaining > 0 {
+		return false, nil
+	}
+
+	if err := q.DeleteUnreferencedObject(ctx, objectName); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteReleasedObject runs remove for an object whose last reference was
+// released, unless it has been acquired again since. The object lock is held
+// while remove runs, so a concurrent upload of the same object waits and then
+// uploads it again instead of reusing files that are being deleted.
+func (p *Postgres) DeleteReleasedObject(ctx context.Context, objectName string, remove func()) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		if err := q.LockObjectName(ctx, objectName); err != nil {
+			return err
+		}
+
+		referenced, err := q.IsObjectReferenced(ctx, objectName)
+		if err != nil {
+			return err
+		}
+		if !referenced {
+			remove()
+		}
+		return nil
+	})
+}
+
+func (p *Postgres) ListMigratedObjects(ctx context.Context, target string) (map[string]string, error) {
+	rows, err := p.db.Queries().ListMigratedObjects(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string, len(rows))
+	for _, row := range rows {
+		checksums[row.ObjectName] = row.Checksum
+	}
+
+	return checksums, nil
+}
+
+func (p *Postgres) RecordMigratedObject(ctx context.Context, target, objectName, checksum string, size int64) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		return q.RecordMigratedObject(ctx, generated.RecordMigratedObjectParams{
+			Target:     target,
+			ObjectName: objectName,
+			Checksum:   checksum,
+			Size:       size,
+		})
+	})
+}
@@ -10,10 +10,19 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-func (p *Postgres) UpdateArtwork(ctx context.Context, id uuid.UUID, payload *domain.ArtworkPayload) (*domain.Artwork, error) {
+func (p *Postgres) UpdateArtwork(
+	ctx context.Context,
+	id uuid.UUID,
+	payload *domain.ArtworkPayload,
+	callback func(entries []domain.CatalogEntry) error,
+) (*domain.Artwork, error) {
 	var artwork *domain.Artwork
 
 	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		if err := p.checkCatalogNumbers(ctx, q, callback); err != nil {
+			return err
+		}
+
 		params, err := p.toUpdateArtworkParams(id, payload)
 		if err != nil {
 			return err
@@ -39,11 +48,31 @@ func (p *Postgres) UpdateArtwork(ctx context.Context, id uuid.UUID, payload *dom
 	return artwork, nil
 }
 
-func (p *Postgres) UpdateImage(ctx context.Context, id uuid.UUID, isMainImage bool) (*domain.Image, error) {
+func (p *Postgres) UpdateImage(
+	ctx context.Context,
+	id uuid.UUID,
+	payload *domain.UpdateImagePayload,
+	callback func(current *domain.Image) error,
+) (*domain.Image, error) {
 	var image *domain.Image
 
 	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
-		params := p.toUpdateImageParams(id, isMainImage)
+		current, err := q.GetImageForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := callback(toDomainImage(&current)); err != nil {
+			return err
+		}
+
+		params := p.toUpdateImageParams(&current, payload)
+
+		if params.IsMainImage && !current.IsMainImage {
+			if err := q.ClearMainImage(ctx, current.ArtworkID); err != nil {
+				return err
+			}
+		}
 
 		row, err := q.UpdateImage(ctx, *params)
 		if err != nil {
@@ -63,14 +92,181 @@ func (p *Postgres) UpdateImage(ctx context.Context, id uuid.UUID, isMainImage bo
 
 func (p *Postgres) SetImageAsMain(ctx context.Context, artID, id uuid.UUID) error {
 	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		artworkID := pgtype.UUID{Bytes: artID, Valid: true}
+		if err := q.ClearMainImage(ctx, artworkID); err != nil {
+			return err
+		}
+
 		params := generated.SetMainImageParams{
-			ArtworkID: pgtype.UUID{Bytes: artID, Valid: true},
+			ArtworkID: artworkID,
 			ID:        id,
 		}
 		return q.SetMainImage(ctx, params)
 	})
 }
 
+func (p *Postgres) UpdateImagePHash(ctx context.Context, id uuid.UUID, hash int64) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		return q.UpdateImagePHash(ctx, generated.UpdateImagePHashParams{ID: id, Phash: &hash})
+	})
+}
+
+func (p *Postgres) UpdateImagePalette(ctx context.Context, id uuid.UUID, palette []domain.PaletteColor) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		data, err := toPaletteJSON(palette)
+		if err != nil {
+			return err
+		}
+		return q.UpdateImagePalette(ctx, generated.UpdateImagePaletteParams{ID: id, Palette: data})
+	})
+}
+
+// ReplaceImageFile returns the updated image and, when the replaced files lost
+// their last reference, the previous image so they can be deleted.
+func (p *Postgres) ReplaceImageFile(ctx context.Context, id uuid.UUID, data *domain.CreateImagePayload) (*domain.Image, *domain.Image, error) {
+	var image, released *domain.Image
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		current, err := q.GetImageForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		previous := toDomainImage(&current)
+
+		variants, err := toImageVariantsJSON(data.Variants)
+		if err != nil {
+			return err
+		}
+
+		palette, err := toPaletteJSON(data.Palette)
+		if err != nil {
+			return err
+		}
+
+		row, err := q.ReplaceImageFile(ctx, generated.ReplaceImageFileParams{
+			ID:            id,
+			ObjectName:    data.ObjectName,
+			ImageUrl:      data.ImageURL,
+			ImageWidth:    data.ImageWidth,
+			ImageHeight:   data.ImageHeight,
+			Variants:      variants,
+			Blurhash:      data.BlurHash,
+			DominantColor: data.DominantColor,
+			Phash:         data.PHash,
+			Palette:       palette,
+		})
+		if err != nil {
+			return err
+		}
+		image = toDomainImage(&row)
+
+		last, err := releaseObject(ctx, q, previous.ObjectName)
+		if err != nil {
+			return err
+		}
+		if last {
+			released = previous
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return image, released, nil
+}
+
+func (p *Postgres) UpdateImageVariants(ctx context.Context, id uuid.UUID, variants []domain.ImageVariant) (*domain.Image, error) {
+	var image *domain.Image
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		data, err := toImageVariantsJSON(variants)
+		if err != nil {
+			return err
+		}
+
+		row, err := q.UpdateImageVariants(ctx, generated.UpdateImageVariantsParams{ID: id, Variants: data})
+		if err != nil {
+			return err
+		}
+
+		image = toDomainImage(&row)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (p *Postgres) ReorderImages(ctx context.Context, artID uuid.UUID, callback func(current []uuid.UUID) ([]uuid.UUID, error)) error {
+	return p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		artworkID := pgtype.UUID{Bytes: artID, Valid: true}
+
+		current, err := q.ListImagePositionsForUpdate(ctx, artworkID)
+		if err != nil {
+			return err
+		}
+
+		ordered, err := callback(current)
+		if err != nil {
+			return err
+		}
+
+		return q.UpdateImagePositions(ctx, generated.UpdateImagePositionsParams{
+			ArtworkID: artworkID,
+			Column2:   ordered,
+		})
+	})
+}
+
+func (p *Postgres) ReorderArtworks(
+	ctx context.Context,
+	callback func(current []domain.ArtworkSortOrder) ([]domain.ArtworkSortOrder, error),
+) ([]domain.ArtworkSortOrder, error) {
+	var updated []domain.ArtworkSortOrder
+
+	err := p.db.DoTx(ctx, func(ctx context.Context, q *generated.Queries) error {
+		rows, err := q.ListArtworkSortOrdersForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+
+		current := make([]domain.ArtworkSortOrder, len(rows))
+		for i, row := range rows {
+			current[i] = domain.ArtworkSortOrder{ID: row.ID, SortOrder: row.SortOrder}
+		}
+
+		updated, err = callback(current)
+		if err != nil {
+			return err
+		}
+		if len(updated) == 0 {
+			return nil
+		}
+
+		params := generated.UpdateArtworkSortOrdersParams{
+			Column1: make([]uuid.UUID, len(updated)),
+			Column2: make([]int32, len(updated)),
+		}
+		for i, item := range updated {
+			params.Column1[i] = item.ID
+			params.Column2[i] = item.SortOrder
+		}
+
+		return q.UpdateArtworkSortOrders(ctx, params)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
 func (p *Postgres) UpdateArtworksAsPurchased(
 	ctx context.Context,
 	ids []uuid.UUID,
@@ -116,6 +312,16 @@ func (p *Postgres) toUpdateArtworkParams(id uuid.UUID, payload *domain.ArtworkPa
 		return nil, err
 	}
 
+	depthInches, err := utils.NumericFromFloatPtr(payload.DepthInches)
+	if err != nil {
+		return nil, err
+	}
+
+	weightPounds, err := utils.NumericFromFloatPtr(payload.WeightPounds)
+	if err != nil {
+		return nil, err
+	}
+
 	return &generated.UpdateArtworkParams{
 		ID:             id,
 		Title:          payload.Title,
@@ -123,6 +329,8 @@ func (p *Postgres) toUpdateArtworkParams(id uuid.UUID, payload *domain.ArtworkPa
 		PaintingYear:   payload.PaintingYear,
 		WidthInches:    widthInches,
 		HeightInches:   heightInches,
+		DepthInches:    depthInches,
+		WeightPounds:   weightPounds,
 		PriceCents:     int32(payload.PriceCents),
 		Description:    &payload.Description,
 		Paper:          &payload.Paper,
@@ -133,9 +341,27 @@ func (p *Postgres) toUpdateArtworkParams(id uuid.UUID, payload *domain.ArtworkPa
 	}, nil
 }
 
-func (p *Postgres) toUpdateImageParams(id uuid.UUID, isMainImage bool) *generated.UpdateImageParams {
-	return &generated.UpdateImageParams{
-		ID:          id,
-		IsMainImage: isMainImage,
+func (p *Postgres) toUpdateImageParams(current *generated.Image, payload *domain.UpdateImagePayload) *generated.UpdateImageParams {
+	params := &generated.UpdateImageParams{
+		ID:          current.ID,
+		IsMainImage: current.IsMainImage,
+		AltText:     current.AltText,
+		Caption:     current.Caption,
+		Credit:      current.Credit,
+	}
+
+	if payload.IsMainImage != nil {
+		params.IsMainImage = *payload.IsMainImage
 	}
+	if payload.AltText != nil {
+		params.AltText = utils.NilIfEmpty(*payload.AltText)
+	}
+	if payload.Caption != nil {
+		params.Caption = utils.NilIfEmpty(*payload.Caption)
+	}
+	if payload.Credit != nil {
+		params.Credit = utils.NilIfEmpty(*payload.Credit)
+	}
+
+	return params
 }
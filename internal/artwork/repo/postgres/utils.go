@@ -1,10 +1,12 @@
 package postgres
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/platform/db/generated"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
 	"github.com/google/uuid"
 )
 
@@ -19,6 +21,16 @@ func toDomainArtwork(row *generated.Artwork) (*domain.Artwork, error) {
 		return nil, err
 	}
 
+	depthInches, err := utils.FloatPtrFromNumeric(row.DepthInches)
+	if err != nil {
+		return nil, err
+	}
+
+	weightPounds, err := utils.FloatPtrFromNumeric(row.WeightPounds)
+	if err != nil {
+		return nil, err
+	}
+
 	var description string
 	if row.Description != nil {
 		description = *row.Description
@@ -36,6 +48,8 @@ func toDomainArtwork(row *generated.Artwork) (*domain.Artwork, error) {
 		PaintingYear:   row.PaintingYear,
 		WidthInches:    widthInches.Float64,
 		HeightInches:   heightInches.Float64,
+		DepthInches:    depthInches,
+		WeightPounds:   weightPounds,
 		PriceCents:     row.PriceCents,
 		Paper:          row.Paper,
 		Description:    description,
@@ -50,12 +64,57 @@ func toDomainArtwork(row *generated.Artwork) (*domain.Artwork, error) {
 
 func toDomainImage(row *generated.Image) *domain.Image {
 	return &domain.Image{
-		ArtworkID:   uuid.UUID(row.ArtworkID.Bytes),
-		ID:          row.ID,
-		ObjectName:  row.ObjectName,
-		ImageURL:    row.ImageUrl,
-		IsMainImage: row.IsMainImage,
-		ImageWidth:  row.ImageWidth,
-		ImageHeight: row.ImageHeight,
+		ArtworkID:     uuid.UUID(row.ArtworkID.Bytes),
+		ID:            row.ID,
+		ObjectName:    row.ObjectName,
+		ImageURL:      row.ImageUrl,
+		IsMainImage:   row.IsMainImage,
+		ImageWidth:    row.ImageWidth,
+		ImageHeight:   row.ImageHeight,
+		Variants:      toDomainImageVariants(row.Variants),
+		Palette:       toDomainPalette(row.Palette),
+		BlurHash:      row.Blurhash,
+		DominantColor: row.DominantColor,
+		AltText:       row.AltText,
+		Caption:       row.Caption,
+		Credit:        row.Credit,
+		Position:      row.Position,
+		PHash:         row.Phash,
+	}
+}
+
+func toDomainImageVariants(data []byte) []domain.ImageVariant {
+	variants := []domain.ImageVariant{}
+	if len(data) == 0 {
+		return variants
+	}
+	if err := json.Unmarshal(data, &variants); err != nil {
+		return []domain.ImageVariant{}
+	}
+	return variants
+}
+
+func toImageVariantsJSON(variants []domain.ImageVariant) ([]byte, error) {
+	if variants == nil {
+		variants = []domain.ImageVariant{}
+	}
+	return json.Marshal(variants)
+}
+
+func toDomainPalette(data []byte) []domain.PaletteColor {
+	palette := []domain.PaletteColor{}
+	if len(data) == 0 {
+		return palette
+	}
+	if err := json.Unmarshal(data, &palette); err != nil {
+		return []domain.PaletteColor{}
+	}
+	return palette
+}
+
+func toPaletteJSON(palette []domain.PaletteColor) ([]byte, error) {
+	if palette == nil {
+		palette = []domain.PaletteColor{}
 	}
+	return json.Marshal(palette)
 }
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/artwork/repo/postgres"
@@ -11,16 +12,39 @@ import (
 
 type Repo interface {
 	ListArtworks(ctx context.Context, statuses []domain.ArtworkStatus) ([]domain.Artwork, error)
-	CreateArtwork(ctx context.Context, body *domain.ArtworkPayload) (*domain.Artwork, error)
+	CreateArtwork(ctx context.Context, body *domain.ArtworkPayload, callback func(entries []domain.CatalogEntry) error) (*domain.Artwork, error)
 	CreateImage(ctx context.Context, data *domain.CreateImagePayload) (*domain.Image, error)
 	GetArtworkDetail(ctx context.Context, id uuid.UUID) (*domain.Artwork, error)
 	GetImageDetail(ctx context.Context, id uuid.UUID) (*domain.Image, error)
-	UpdateArtwork(ctx context.Context, id uuid.UUID, payload *domain.ArtworkPayload) (*domain.Artwork, error)
-	UpdateImage(ctx context.Context, id uuid.UUID, isMainImage bool) (*domain.Image, error)
+	ListImages(ctx context.Context) ([]domain.Image, error)
+	ListArtworksWithMainImage(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	ListSimilarImages(ctx context.Context, id uuid.UUID, hash int64, maxDistance int32) ([]domain.SimilarImage, error)
+	UpdateArtwork(ctx context.Context, id uuid.UUID, payload *domain.ArtworkPayload, callback func(entries []domain.CatalogEntry) error) (*domain.Artwork, error)
+	UpdateImage(ctx context.Context, id uuid.UUID, payload *domain.UpdateImagePayload, callback func(current *domain.Image) error) (*domain.Image, error)
+	ReplaceImageFile(ctx context.Context, id uuid.UUID, data *domain.CreateImagePayload) (*domain.Image, *domain.Image, error)
+	UpdateImageVariants(ctx context.Context, id uuid.UUID, variants []domain.ImageVariant) (*domain.Image, error)
+	UpdateImagePHash(ctx context.Context, id uuid.UUID, hash int64) error
+	UpdateImagePalette(ctx context.Context, id uuid.UUID, palette []domain.PaletteColor) error
+	UpdateImageURLs(ctx context.Context, id uuid.UUID, rewrite func(current *domain.Image) (string, []domain.ImageVariant, error)) (*domain.Image, error)
 	SetImageAsMain(ctx context.Context, artID, id uuid.UUID) error
+	ReorderImages(ctx context.Context, artID uuid.UUID, callback func(current []uuid.UUID) ([]uuid.UUID, error)) error
 	DeleteArtwork(ctx context.Context, id uuid.UUID) error
-	DeleteImage(ctx context.Context, id uuid.UUID) error
+	DeleteImage(ctx context.Context, id uuid.UUID) (*domain.Image, error)
+	ListCatalogEntries(ctx context.Context) ([]domain.CatalogEntry, error)
+	GetCatalogNumbering(ctx context.Context) (domain.NumberingScheme, error)
+	SwitchCatalogNumbering(ctx context.Context, scheme domain.NumberingScheme) error
+	AcquireObjectReference(ctx context.Context, objectName string) error
+	ReleaseObjectReference(ctx context.Context, objectName string) (bool, error)
+	DeleteReleasedObject(ctx context.Context, objectName string, remove func()) error
+	ListMigratedObjects(ctx context.Context, target string) (map[string]string, error)
+	RecordMigratedObject(ctx context.Context, target, objectName, checksum string, size int64) error
+	GetArtworksByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Artwork, error)
+	CreatePreviewLink(ctx context.Context, ids []uuid.UUID, label *string, expiresAt time.Time) (*domain.PreviewLink, error)
+	ListPreviewLinks(ctx context.Context) ([]domain.PreviewLink, error)
+	RecordPreviewLinkView(ctx context.Context, id uuid.UUID) (*domain.PreviewLink, error)
+	RevokePreviewLink(ctx context.Context, id uuid.UUID) error
 	GetArtworkCheckoutData(ctx context.Context, ids []uuid.UUID) ([]domain.Artwork, error)
+	ReorderArtworks(ctx context.Context, callback func(current []domain.ArtworkSortOrder) ([]domain.ArtworkSortOrder, error)) ([]domain.ArtworkSortOrder, error)
 	UpdateArtworksAsPurchased(ctx context.Context, ids []uuid.UUID, orderID uuid.UUID, callback func(selectedIDs []uuid.UUID) error) error
 }
 
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+)
+
+// ResolveImageURLs swaps the stored URL of private originals for one the
+// caller can open: a short-lived signed URL for admins, or the largest public
+// derivative for everyone else. Public originals are left untouched.
+func (s *ImageService) ResolveImageURLs(ctx context.Context, images []domain.Image, admin bool) error {
+	for i := range images {
+		if err := s.ResolveImageURL(ctx, &images[i], admin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ImageService) ResolveImageURL(ctx context.Context, img *domain.Image, admin bool) error {
+	if img == nil {
+		return nil
+	}
+	if err := s.ResolveSimilarURLs(ctx, img.Duplicates); err != nil {
+		return err
+	}
+
+	if !storage.IsPrivateObject(img.ObjectName) {
+		return nil
+	}
+	if !admin {
+		img.ImageURL = PublicImageURL(img)
+		return nil
+	}
+
+	signed, err := s.signedOriginalURL(ctx, img.ObjectName)
+	if err != nil {
+		return err
+	}
+	img.ImageURL = signed
+
+	return nil
+}
+
+func (s *ImageService) ResolveSimilarURLs(ctx context.Context, images []domain.SimilarImage) error {
+	for i := range images {
+		if !storage.IsPrivateObject(images[i].ObjectName) {
+			continue
+		}
+		signed, err := s.signedOriginalURL(ctx, images[i].ObjectName)
+		if err != nil {
+			return err
+		}
+		images[i].ImageURL = signed
+	}
+	return nil
+}
+
+func (s *ImageService) ResolveReportURLs(ctx context.Context, report *domain.ImageUploadReport) error {
+	for _, result := range report.Results {
+		if err := s.ResolveImageURL(ctx, result.Image, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ArtworkService) ResolveImageURLs(ctx context.Context, artworks []domain.Artwork, admin bool) error {
+	for i := range artworks {
+		if err := s.ResolveArtworkURLs(ctx, &artworks[i], admin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ArtworkService) ResolveArtworkURLs(ctx context.Context, artwork *domain.Artwork, admin bool) error {
+	return s.imageService.ResolveImageURLs(ctx, artwork.Images, admin)
+}
+
+func (s *ArtworkService) ResolveReportURLs(ctx context.Context, report *domain.ImageUploadReport) error {
+	return s.imageService.ResolveReportURLs(ctx, report)
+}
+
+func (s *ImageService) signedOriginalURL(ctx context.Context, objectName string) (string, error) {
+	return s.provider.SignedReadURL(ctx, objectName, time.Now().Add(s.env.SignedURLExpiry))
+}
+
+func PublicImageURL(img *domain.Image) string {
+	if !storage.IsPrivateObject(img.ObjectName) {
+		return img.ImageURL
+	}
+	if largest := img.LargestVariant(); largest != nil {
+		return largest.URL
+	}
+	return ""
+}
+
+func hidePrivateOriginals(artworks []domain.Artwork) {
+	for i := range artworks {
+		for j := range artworks[i].Images {
+			image := &artworks[i].Images[j]
+			image.ImageURL = PublicImageURL(image)
+		}
+	}
+}
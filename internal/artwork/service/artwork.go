@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/platform/config"
 	"github.com/art-vbst/art-backend/internal/platform/storage"
 	"github.com/google/uuid"
 )
@@ -17,21 +20,63 @@ var (
 type ArtworkService struct {
 	repo         repo.Repo
 	imageService *ImageService
+	numbering    domain.NumberingScheme
+	mockup       *mockupTemplate
 }
 
-func NewArtworkService(repo repo.Repo, provider storage.Provider) *ArtworkService {
-	return &ArtworkService{repo: repo, imageService: NewImageService(repo, provider)}
+func NewArtworkService(repo repo.Repo, provider storage.Provider, env *config.Config) (*ArtworkService, error) {
+	mockup, err := newMockupTemplate(env)
+	if err != nil {
+		return nil, fmt.Errorf("load mockup template: %w", err)
+	}
+
+	imageService, err := NewImageService(repo, provider, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArtworkService{
+		repo:         repo,
+		imageService: imageService,
+		numbering:    domain.NumberingScheme(env.CatalogNumbering),
+		mockup:       mockup,
+	}, nil
 }
 
-func (s *ArtworkService) List(ctx context.Context, statuses []domain.ArtworkStatus) ([]domain.Artwork, error) {
-	return s.repo.ListArtworks(ctx, statuses)
+func (s *ArtworkService) List(ctx context.Context, statuses []domain.ArtworkStatus, system domain.MeasurementSystem, color string) ([]domain.Artwork, error) {
+	artworks, err := s.repo.ListArtworks(ctx, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if color != "" {
+		target, err := parseHexColor(color)
+		if err != nil {
+			return nil, err
+		}
+		artworks = slices.DeleteFunc(artworks, func(artwork domain.Artwork) bool {
+			return len(artwork.Images) == 0 || !paletteMatches(artwork.Images[0].Palette, target)
+		})
+	}
+
+	for i := range artworks {
+		artworks[i].Measurements = domain.NewMeasurements(&artworks[i], system)
+	}
+
+	return artworks, nil
 }
 
 func (s *ArtworkService) Create(ctx context.Context, body *domain.ArtworkPayload) (*domain.Artwork, error) {
-	return s.repo.CreateArtwork(ctx, body)
+	artwork, err := s.repo.CreateArtwork(ctx, body, s.assignPaintingNumber(body))
+	if err != nil {
+		return nil, err
+	}
+
+	artwork.Measurements = domain.NewMeasurements(artwork, domain.MeasurementSystemImperial)
+	return artwork, nil
 }
 
-func (s *ArtworkService) Detail(ctx context.Context, id uuid.UUID) (*domain.Artwork, error) {
+func (s *ArtworkService) Detail(ctx context.Context, id uuid.UUID, system domain.MeasurementSystem) (*domain.Artwork, error) {
 	artwork, err := s.repo.GetArtworkDetail(ctx, id)
 	if err != nil {
 		return nil, err
@@ -40,11 +85,18 @@ func (s *ArtworkService) Detail(ctx context.Context, id uuid.UUID) (*domain.Artw
 		return nil, ErrArtworkNotFound
 	}
 
+	artwork.Measurements = domain.NewMeasurements(artwork, system)
 	return artwork, nil
 }
 
 func (s *ArtworkService) Update(ctx context.Context, id uuid.UUID, body *domain.ArtworkPayload) (*domain.Artwork, error) {
-	return s.repo.UpdateArtwork(ctx, id, body)
+	artwork, err := s.repo.UpdateArtwork(ctx, id, body, s.checkPaintingNumber(id, body))
+	if err != nil {
+		return nil, err
+	}
+
+	artwork.Measurements = domain.NewMeasurements(artwork, domain.MeasurementSystemImperial)
+	return artwork, nil
 }
 
 func (s *ArtworkService) Delete(ctx context.Context, id uuid.UUID) error {
@@ -62,5 +114,7 @@ func (s *ArtworkService) Delete(ctx context.Context, id uuid.UUID) error {
 		}
 	}
 
+	s.purgeMockups(ctx, artwork.ID)
+
 	return s.repo.DeleteArtwork(ctx, artwork.ID)
 }
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxUploadFileSize = 12 * utils.MB
+	maxArchiveEntries = 1000
+)
+
+var (
+	ErrInvalidArchive = errors.New("invalid archive")
+	ErrFileTooLarge   = errors.New("file too large")
+)
+
+var archiveFileName = regexp.MustCompile(`^(\d+)-(\d+)\.[A-Za-z0-9]+$`)
+
+// UploadFile is a single uploaded image. When Load is set the content is
+// read only once the file is processed, so a batch keeps one file in memory
+// at a time.
+type UploadFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+	Load        func() ([]byte, error)
+}
+
+func (f *UploadFile) read() ([]byte, error) {
+	if f.Load == nil {
+		return f.Content, nil
+	}
+	return f.Load()
+}
+
+type archiveImage struct {
+	file           *zip.File
+	paintingNumber int32
+	sequence       int
+}
+
+func (s *ImageService) CreateFromFile(ctx context.Context, payload domain.CreateImagePayload, file *UploadFile) (*domain.Image, error) {
+	content, err := file.read()
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := s.PrepareImage(bytes.NewReader(content), file.ContentType, file.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.ImageWidth, payload.ImageHeight = ImageDimensions(prepared.Image)
+
+	return s.Create(ctx, &CreateImageData{
+		UploadFileData: storage.UploadFileData{
+			FileName:    CanonicalFileName(file.FileName, prepared.Format),
+			ContentType: prepared.ContentType,
+		},
+		CreateImagePayload: payload,
+		Image:              prepared.Image,
+		Content:            prepared.Content,
+	})
+}
+
+func (s *ImageService) CreateBatch(ctx context.Context, payload domain.CreateImagePayload, files []UploadFile) *domain.ImageUploadReport {
+	report := domain.NewImageUploadReport()
+
+	for i := range files {
+		item := payload
+		item.IsMainImage = payload.IsMainImage && i == 0
+
+		image, err := s.CreateFromFile(ctx, item, &files[i])
+		if err != nil {
+			report.AddFailure(files[i].FileName, &payload.ArtworkID, uploadErrorMessage(err))
+			continue
+		}
+		report.AddSuccess(files[i].FileName, image)
+	}
+
+	return report
+}
+
+func (s *ArtworkService) ImportImages(ctx context.Context, archive io.ReaderAt, size int64) (*domain.ImageUploadReport, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if len(reader.File) > maxArchiveEntries {
+		return nil, fmt.Errorf("%w: more than %d entries", ErrInvalidArchive, maxArchiveEntries)
+	}
+
+	entries, err := s.repo.ListCatalogEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := map[int32][]uuid.UUID{}
+	for _, entry := range entries {
+		if entry.PaintingNumber != nil {
+			byNumber[*entry.PaintingNumber] = append(byNumber[*entry.PaintingNumber], entry.ID)
+		}
+	}
+
+	report := domain.NewImageUploadReport()
+
+	images := []archiveImage{}
+	for _, file := range reader.File {
+		name := path.Base(file.Name)
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, ".") || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+
+		match := archiveFileName.FindStringSubmatch(name)
+		if match == nil {
+			report.AddFailure(file.Name, nil, "file name does not match <painting_number>-<n>.<ext>")
+			continue
+		}
+
+		number, numberErr := strconv.ParseInt(match[1], 10, 32)
+		sequence, sequenceErr := strconv.Atoi(match[2])
+		if numberErr != nil || sequenceErr != nil {
+			report.AddFailure(file.Name, nil, "file name does not match <painting_number>-<n>.<ext>")
+			continue
+		}
+
+		images = append(images, archiveImage{file: file, paintingNumber: int32(number), sequence: sequence})
+	}
+
+	slices.SortFunc(images, func(a, b archiveImage) int {
+		return cmp.Or(cmp.Compare(a.paintingNumber, b.paintingNumber), cmp.Compare(a.sequence, b.sequence))
+	})
+
+	artworkIDs := []uuid.UUID{}
+	for _, item := range images {
+		if ids := byNumber[item.paintingNumber]; len(ids) == 1 {
+			artworkIDs = append(artworkIDs, ids[0])
+		}
+	}
+
+	withMain, err := s.repo.ListArtworksWithMainImage(ctx, artworkIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMain := map[uuid.UUID]bool{}
+	for _, id := range withMain {
+		hasMain[id] = true
+	}
+
+	for _, item := range images {
+		ids := byNumber[item.paintingNumber]
+		switch len(ids) {
+		case 0:
+			report.AddFailure(item.file.Name, nil, fmt.Sprintf("no artwork with painting number %d", item.paintingNumber))
+			continue
+		case 1:
+		default:
+			report.AddFailure(item.file.Name, nil, fmt.Sprintf("painting number %d matches more than one artwork", item.paintingNumber))
+			continue
+		}
+
+		artworkID := ids[0]
+
+		content, err := readArchiveFile(item.file)
+		if err != nil {
+			report.AddFailure(item.file.Name, &artworkID, uploadErrorMessage(err))
+			continue
+		}
+
+		// Re-imports add images without taking over an existing main image.
+		payload := domain.CreateImagePayload{ArtworkID: artworkID, IsMainImage: !hasMain[artworkID]}
+		file := UploadFile{FileName: path.Base(item.file.Name), Content: content}
+
+		image, err := s.imageService.CreateFromFile(ctx, payload, &file)
+		if err != nil {
+			report.AddFailure(item.file.Name, &artworkID, uploadErrorMessage(err))
+			continue
+		}
+		hasMain[artworkID] = hasMain[artworkID] || image.IsMainImage
+		report.AddSuccess(item.file.Name, image)
+	}
+
+	return report, nil
+}
+
+func readArchiveFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > MaxUploadFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, MaxUploadFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxUploadFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	return content, nil
+}
+
+func uploadErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, ErrUnsupportedFormat),
+		errors.Is(err, ErrContentMismatch),
+		errors.Is(err, ErrPolyglotImage),
+		errors.Is(err, ErrMalformedJPEG),
+		errors.Is(err, ErrMalformedWebP),
+		errors.Is(err, ErrFileTooLarge):
+		return err.Error()
+	default:
+		log.Printf("image upload error: %v", err)
+		return "failed to process image"
+	}
+}
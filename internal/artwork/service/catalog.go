@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDuplicatePaintingNumber = errors.New("painting number already in use")
+)
+
+func (s *ArtworkService) assignPaintingNumber(body *domain.ArtworkPayload) func(entries []domain.CatalogEntry) error {
+	return func(entries []domain.CatalogEntry) error {
+		if body.AutoNumber {
+			next := domain.NextPaintingNumber(entries, s.numbering, body.PaintingYear, body.Medium)
+			body.PaintingNumber = &next
+			return nil
+		}
+		return s.checkPaintingNumber(uuid.Nil, body)(entries)
+	}
+}
+
+func (s *ArtworkService) checkPaintingNumber(id uuid.UUID, body *domain.ArtworkPayload) func(entries []domain.CatalogEntry) error {
+	return func(entries []domain.CatalogEntry) error {
+		if body.PaintingNumber == nil {
+			return nil
+		}
+
+		conflict := domain.FindPaintingNumberConflict(entries, s.numbering, id, *body.PaintingNumber, body.PaintingYear, body.Medium)
+		if conflict != nil {
+			return ErrDuplicatePaintingNumber
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"path"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDirectUploadSize bounds what FinalizeUpload reads back into memory
+	// and decodes within a single request, on top of the decoded pixels.
+	MaxDirectUploadSize  = 32 * utils.MB
+	directUploadPrefix   = "incoming/"
+	directUploadExpiry   = 15 * time.Minute
+	directFinalizeWindow = time.Hour
+
+	// DirectUploadLifetime is how long after a signed upload URL is issued its
+	// object can still be finalized.
+	DirectUploadLifetime = directUploadExpiry + directFinalizeWindow
+)
+
+var (
+	ErrInvalidUpload  = errors.New("invalid upload")
+	ErrUploadNotFound = errors.New("uploaded object not found")
+)
+
+func (s *ImageService) CreateUploadURL(ctx context.Context, artID uuid.UUID, payload *domain.DirectUploadPayload) (*domain.DirectUpload, error) {
+	format := declaredImageTypes[payload.ContentType]
+	if format == "" || format == formatHEIC {
+		return nil, ErrUnsupportedFormat
+	}
+	if err := checkDeclaredFormat(format, "", payload.FileName); err != nil {
+		return nil, err
+	}
+	if payload.Size <= 0 {
+		return nil, ErrInvalidUpload
+	}
+	if payload.Size > MaxDirectUploadSize {
+		return nil, ErrFileTooLarge
+	}
+
+	objectName := directUploadPrefix + path.Base(s.provider.GetObjectName(CanonicalFileName(payload.FileName, format)))
+	expiresAt := time.Now().Add(directUploadExpiry)
+
+	uploadURL, err := s.provider.SignedUploadURL(ctx, objectName, payload.ContentType, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.CreateUploadToken(artID, objectName, payload.FileName, payload.ContentType, expiresAt.Add(directFinalizeWindow), s.env.JwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DirectUpload{
+		UploadURL:   uploadURL,
+		Method:      "PUT",
+		Headers:     map[string]string{"Content-Type": payload.ContentType},
+		UploadToken: token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+func (s *ImageService) FinalizeUpload(ctx context.Context, artID uuid.UUID, payload *domain.FinalizeUploadPayload) (*domain.Image, error) {
+	claims, err := utils.ParseUploadToken(payload.UploadToken, s.env.JwtSecret)
+	if err != nil || claims.ArtworkID != artID {
+		return nil, ErrInvalidUpload
+	}
+
+	content, err := s.readUploadedObject(ctx, claims.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	file := UploadFile{FileName: claims.FileName, ContentType: claims.ContentType, Content: content}
+	image, err := s.CreateFromFile(ctx, domain.CreateImagePayload{
+		ArtworkID:   artID,
+		IsMainImage: payload.IsMainImage,
+		AltText:     payload.AltText,
+		Caption:     payload.Caption,
+		Credit:      payload.Credit,
+	}, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.provider.DeleteObject(ctx, claims.ObjectName); err != nil {
+		log.Printf("failed to delete staged upload %s: %v", claims.ObjectName, err)
+	}
+
+	return image, nil
+}
+
+func (s *ImageService) readUploadedObject(ctx context.Context, objectName string) ([]byte, error) {
+	rc, err := s.provider.GetObject(ctx, objectName)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, MaxDirectUploadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxDirectUploadSize {
+		return nil, ErrFileTooLarge
+	}
+
+	return content, nil
+}
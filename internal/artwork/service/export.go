@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	catalogImageMaxHeight = 150.0
+	catalogLineHeight     = 6.0
+	catalogImageMaxSize   = MaxUploadFileSize
+)
+
+func (s *ArtworkService) ExportCatalog(ctx context.Context, filter *domain.CatalogExportFilter, w io.Writer) error {
+	artworks, err := s.repo.ListArtworks(ctx, filter.Statuses)
+	if err != nil {
+		return err
+	}
+
+	artworks = filterCatalogArtworks(artworks, filter)
+	sortCatalogArtworks(artworks)
+
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetTitle("Catalogue Raisonné", true)
+	pdf.SetCreationDate(time.Now())
+	pdf.AliasNbPages("")
+	pdf.SetAutoPageBreak(true, 15)
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	for i := range artworks {
+		s.writeCatalogEntry(ctx, pdf, tr, &artworks[i])
+		if err := pdf.Error(); err != nil {
+			return err
+		}
+	}
+
+	if len(artworks) == 0 {
+		pdf.AddPage()
+		pdf.SetFont("Helvetica", "", 12)
+		pdf.Cell(0, catalogLineHeight, "No artworks match the selected filters.")
+	}
+
+	return pdf.Output(w)
+}
+
+func (s *ArtworkService) writeCatalogEntry(ctx context.Context, pdf *fpdf.Fpdf, tr func(string) string, artwork *domain.Artwork) {
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, top, right, _ := pdf.GetMargins()
+	contentWidth := pageWidth - left - right
+
+	if len(artwork.Images) > 0 {
+		name := artwork.Images[0].ID.String()
+		if info := s.registerCatalogImage(ctx, pdf, name, &artwork.Images[0]); info != nil {
+			width, height := fitImage(info.Width(), info.Height(), contentWidth, catalogImageMaxHeight)
+			x := left + (contentWidth-width)/2
+			pdf.ImageOptions(name, x, top, width, height, false, fpdf.ImageOptions{ImageType: "JPG"}, 0, "")
+			pdf.SetY(top + height + 8)
+		}
+	}
+
+	title := artwork.Title
+	if artwork.PaintingNumber != nil {
+		title = fmt.Sprintf("No. %d — %s", *artwork.PaintingNumber, artwork.Title)
+	}
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.MultiCell(contentWidth, 7, tr(title), "", "L", false)
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "", 11)
+	for _, line := range catalogEntryDetails(artwork) {
+		pdf.MultiCell(contentWidth, catalogLineHeight, tr(line), "", "L", false)
+	}
+}
+
+func (s *ArtworkService) registerCatalogImage(ctx context.Context, pdf *fpdf.Fpdf, name string, image *domain.Image) *fpdf.ImageInfoType {
+	objectName := catalogImageObject(image)
+
+	data, err := s.fetchCatalogImage(ctx, objectName)
+	if err != nil {
+		log.Printf("catalog export: skipping image %s: %v", objectName, err)
+		return nil
+	}
+
+	info := pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "JPG"}, bytes.NewReader(data))
+	if pdf.Err() {
+		log.Printf("catalog export: skipping image %s: %v", objectName, pdf.Error())
+		pdf.ClearError()
+		return nil
+	}
+
+	return info
+}
+
+// catalogImageObject prefers the largest stored variant, which is plenty for
+// print, and only falls back to the original for images without variants.
+func catalogImageObject(image *domain.Image) string {
+	if largest := image.LargestVariant(); largest != nil {
+		return largest.ObjectName
+	}
+	return image.ObjectName
+}
+
+func (s *ArtworkService) fetchCatalogImage(ctx context.Context, objectName string) ([]byte, error) {
+	rc, err := s.imageService.provider.GetObject(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, catalogImageMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > catalogImageMaxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	if http.DetectContentType(data) == "image/jpeg" {
+		return data, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func catalogEntryDetails(artwork *domain.Artwork) []string {
+	lines := []string{}
+
+	if artwork.PaintingYear != nil {
+		lines = append(lines, fmt.Sprintf("Year: %d", *artwork.PaintingYear))
+	}
+
+	lines = append(lines, fmt.Sprintf("Medium: %s", humanize(string(artwork.Medium))))
+	lines = append(lines, fmt.Sprintf("Dimensions: %s", domain.NewMeasurements(artwork, domain.MeasurementSystemImperial).Display))
+	lines = append(lines, fmt.Sprintf("Category: %s", humanize(string(artwork.Category))))
+
+	status := fmt.Sprintf("Status: %s", humanize(string(artwork.Status)))
+	if artwork.SoldAt != nil {
+		status += fmt.Sprintf(" (%s)", artwork.SoldAt.Format("January 2, 2006"))
+	}
+	lines = append(lines, status)
+
+	return lines
+}
+
+func filterCatalogArtworks(artworks []domain.Artwork, filter *domain.CatalogExportFilter) []domain.Artwork {
+	return slices.DeleteFunc(artworks, func(artwork domain.Artwork) bool {
+		if len(filter.Categories) > 0 && !slices.Contains(filter.Categories, artwork.Category) {
+			return true
+		}
+		if filter.YearFrom == nil && filter.YearTo == nil {
+			return false
+		}
+		if artwork.PaintingYear == nil {
+			return true
+		}
+		if filter.YearFrom != nil && *artwork.PaintingYear < *filter.YearFrom {
+			return true
+		}
+		if filter.YearTo != nil && *artwork.PaintingYear > *filter.YearTo {
+			return true
+		}
+		return false
+	})
+}
+
+func sortCatalogArtworks(artworks []domain.Artwork) {
+	slices.SortStableFunc(artworks, func(a, b domain.Artwork) int {
+		switch {
+		case a.PaintingNumber == nil && b.PaintingNumber == nil:
+			return strings.Compare(a.Title, b.Title)
+		case a.PaintingNumber == nil:
+			return 1
+		case b.PaintingNumber == nil:
+			return -1
+		default:
+			return cmp.Compare(*a.PaintingNumber, *b.PaintingNumber)
+		}
+	})
+}
+
+func fitImage(width, height, maxWidth, maxHeight float64) (float64, float64) {
+	scale := min(maxWidth/width, maxHeight/height)
+	return width * scale, height * scale
+}
+
+func humanize(value string) string {
+	value = strings.ReplaceAll(value, "_", " ")
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
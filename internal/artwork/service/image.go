@@ -1,52 +1,64 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
-	"io"
-	"mime/multipart"
+	"log"
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/platform/config"
 	"github.com/art-vbst/art-backend/internal/platform/storage"
 	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
 )
 
 var (
 	ErrUnsupportedFormat = errors.New("unsupported format")
 	ErrInvalidArtID      = errors.New("artwork id does not match")
+	ErrInvalidImageOrder = errors.New("invalid image order")
 )
 
 type ImageService struct {
-	repo     repo.Repo
-	provider storage.Provider
+	repo      repo.Repo
+	provider  storage.Provider
+	env       *config.Config
+	watermark *watermark
 }
 
-func NewImageService(repo repo.Repo, provider storage.Provider) *ImageService {
-	return &ImageService{repo: repo, provider: provider}
+func NewImageService(repo repo.Repo, provider storage.Provider, env *config.Config) (*ImageService, error) {
+	mark, err := newWatermark(env)
+	if err != nil {
+		return nil, fmt.Errorf("load watermark: %w", err)
+	}
+
+	return &ImageService{repo: repo, provider: provider, env: env, watermark: mark}, nil
 }
 
 type CreateImageData struct {
 	storage.UploadFileData
 	domain.CreateImagePayload
+	Image   image.Image
+	Content []byte
 }
 
 func (s *ImageService) Create(ctx context.Context, data *CreateImageData) (*domain.Image, error) {
-	data.ObjectName = s.provider.GetObjectName(data.FileName)
-	data.ImageURL = s.provider.GetObjectURL(data.ObjectName)
-
-	if err := s.provider.UploadObject(data.ObjectName, data.ContentType, data.File); err != nil {
+	if err := s.storeImageFiles(ctx, data); err != nil {
 		return nil, err
 	}
 
 	image, err := s.repo.CreateImage(ctx, &data.CreateImagePayload)
 	if err != nil {
+		s.releaseImageFiles(context.WithoutCancel(ctx), data.ObjectName, data.Variants)
 		return nil, err
 	}
+	image.Duplicates = s.findDuplicates(ctx, image)
 
 	if data.IsMainImage {
 		if err := s.repo.SetImageAsMain(ctx, image.ArtworkID, image.ID); err != nil {
@@ -57,16 +69,154 @@ func (s *ImageService) Create(ctx context.Context, data *CreateImageData) (*doma
 	return image, nil
 }
 
-func (s *ImageService) Update(ctx context.Context, artID, id uuid.UUID, isMainImage bool) (*domain.Image, error) {
-	image, err := s.repo.UpdateImage(ctx, id, isMainImage)
+func (s *ImageService) ReplaceFile(ctx context.Context, artID, id uuid.UUID, file *UploadFile) (*domain.Image, error) {
+	current, err := s.repo.GetImageDetail(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if image.ArtworkID != artID {
+	if current.ArtworkID != artID {
 		return nil, ErrInvalidArtID
 	}
 
-	if isMainImage {
+	prepared, err := s.PrepareImage(bytes.NewReader(file.Content), file.ContentType, file.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &CreateImageData{
+		UploadFileData: storage.UploadFileData{
+			FileName:    CanonicalFileName(file.FileName, prepared.Format),
+			ContentType: prepared.ContentType,
+		},
+		Image:   prepared.Image,
+		Content: prepared.Content,
+	}
+	data.ImageWidth, data.ImageHeight = ImageDimensions(prepared.Image)
+
+	if err := s.storeImageFiles(ctx, data); err != nil {
+		return nil, err
+	}
+
+	cleanupCtx := context.WithoutCancel(ctx)
+	image, released, err := s.repo.ReplaceImageFile(cleanupCtx, id, &data.CreateImagePayload)
+	if err != nil {
+		s.releaseImageFiles(cleanupCtx, data.ObjectName, data.Variants)
+		return nil, err
+	}
+	if released != nil {
+		s.deleteReleasedFiles(cleanupCtx, released.ObjectName, released.Variants)
+	}
+	s.purgeResized(cleanupCtx, id)
+
+	image.Duplicates = s.findDuplicates(ctx, image)
+	return image, nil
+}
+
+// storeImageFiles takes a reference to the object before checking or uploading
+// it, so its files cannot be deleted by a concurrent release in the meantime.
+// The caller owns that reference once this returns without error.
+func (s *ImageService) storeImageFiles(ctx context.Context, data *CreateImageData) error {
+	data.ObjectName = s.provider.GetObjectName(data.FileName)
+	if s.env.StorageNaming == "content" {
+		data.ObjectName = storage.ContentObjectName(data.Content, data.FileName)
+	}
+	if s.env.PrivateOriginals {
+		data.ObjectName = storage.PrivateObjectPrefix + data.ObjectName
+	}
+	data.ImageURL = s.provider.GetObjectURL(data.ObjectName)
+
+	if err := s.repo.AcquireObjectReference(ctx, data.ObjectName); err != nil {
+		return err
+	}
+	if err := s.uploadImageFiles(ctx, data); err != nil {
+		s.releaseImageFiles(context.WithoutCancel(ctx), data.ObjectName, data.Variants)
+		return err
+	}
+
+	return nil
+}
+
+func (s *ImageService) uploadImageFiles(ctx context.Context, data *CreateImageData) error {
+	exists := false
+	if s.env.StorageNaming == "content" {
+		var err error
+		if exists, err = s.provider.ObjectExists(ctx, data.ObjectName); err != nil {
+			return err
+		}
+	}
+	if !exists {
+		if err := s.provider.UploadObject(ctx, data.ObjectName, data.ContentType, bytes.NewReader(data.Content)); err != nil {
+			return err
+		}
+	}
+
+	variants, err := s.createVariants(ctx, data.Image, data.ObjectName)
+	if err != nil {
+		return err
+	}
+	data.Variants = variants
+
+	blurHash, color, err := computePlaceholders(data.Image)
+	if err != nil {
+		return err
+	}
+	data.BlurHash = blurHash
+	data.DominantColor = color
+	data.Palette = extractPalette(data.Image)
+
+	hash := perceptualHash(data.Image)
+	data.PHash = &hash
+
+	return nil
+}
+
+func (s *ImageService) releaseImageFiles(ctx context.Context, objectName string, variants []domain.ImageVariant) {
+	released, err := s.repo.ReleaseObjectReference(ctx, objectName)
+	if err != nil {
+		log.Printf("failed to release object %s: %v", objectName, err)
+		return
+	}
+	if released {
+		s.deleteReleasedFiles(ctx, objectName, variants)
+	}
+}
+
+// deleteReleasedFiles deletes the files of an object only after the
+// transaction dropping its last reference has committed. Anything left behind
+// by a failure here is an orphan for reconcilestorage.
+func (s *ImageService) deleteReleasedFiles(ctx context.Context, objectName string, variants []domain.ImageVariant) {
+	err := s.repo.DeleteReleasedObject(ctx, objectName, func() {
+		s.deleteImageFiles(ctx, objectName, variants)
+	})
+	if err != nil {
+		log.Printf("failed to delete released object %s: %v", objectName, err)
+	}
+}
+
+func (s *ImageService) deleteImageFiles(ctx context.Context, objectName string, variants []domain.ImageVariant) {
+	for _, variant := range variants {
+		if err := s.provider.DeleteObject(ctx, variant.ObjectName); err != nil {
+			log.Printf("failed to delete variant %s: %v", variant.ObjectName, err)
+		}
+	}
+
+	if err := s.provider.DeleteObject(ctx, objectName); err != nil {
+		log.Printf("failed to delete object %s: %v", objectName, err)
+	}
+}
+
+func (s *ImageService) Update(ctx context.Context, artID, id uuid.UUID, payload *domain.UpdateImagePayload) (*domain.Image, error) {
+	image, err := s.repo.UpdateImage(ctx, id, payload, func(current *domain.Image) error {
+		if current.ArtworkID != artID {
+			return ErrInvalidArtID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.IsMainImage != nil && *payload.IsMainImage {
 		if err := s.repo.SetImageAsMain(ctx, artID, id); err != nil {
 			return nil, err
 		}
@@ -84,33 +234,57 @@ func (s *ImageService) Delete(ctx context.Context, artID, id uuid.UUID) error {
 		return ErrInvalidArtID
 	}
 
-	if err := s.provider.DeleteObject(img.ObjectName); err != nil {
+	cleanupCtx := context.WithoutCancel(ctx)
+	released, err := s.repo.DeleteImage(cleanupCtx, id)
+	if err != nil {
 		return err
 	}
+	if released != nil {
+		s.deleteReleasedFiles(cleanupCtx, released.ObjectName, released.Variants)
+	}
+	s.purgeResized(cleanupCtx, id)
 
-	return s.repo.DeleteImage(ctx, id)
+	return nil
 }
 
-func (h *ImageService) GetImageDimensions(file multipart.File) (*int32, *int32, error) {
-	if _, err := file.Seek(0, io.SeekStart); err != nil {
-		return nil, nil, err
-	}
+func ImageDimensions(img image.Image) (*int32, *int32) {
+	bounds := img.Bounds()
+	width := int32(bounds.Dx())
+	height := int32(bounds.Dy())
 
-	img, _, err := image.Decode(file)
-	if err != nil {
-		if errors.Is(err, image.ErrFormat) {
-			return nil, nil, ErrUnsupportedFormat
+	return &width, &height
+}
+
+func (s *ImageService) Reorder(ctx context.Context, artID uuid.UUID, payload *domain.ImageOrderPayload) ([]domain.Image, error) {
+	err := s.repo.ReorderImages(ctx, artID, func(current []uuid.UUID) ([]uuid.UUID, error) {
+		if len(current) == 0 {
+			return nil, ErrArtworkNotFound
+		}
+		if len(payload.IDs) != len(current) {
+			return nil, ErrInvalidImageOrder
 		}
-		return nil, nil, err
-	}
 
-	if _, err := file.Seek(0, io.SeekStart); err != nil {
-		return nil, nil, err
+		remaining := make(map[uuid.UUID]bool, len(current))
+		for _, id := range current {
+			remaining[id] = true
+		}
+		for _, id := range payload.IDs {
+			if !remaining[id] {
+				return nil, ErrInvalidImageOrder
+			}
+			delete(remaining, id)
+		}
+
+		return payload.IDs, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	bounds := img.Bounds()
-	width := int32(bounds.Dx())
-	height := int32(bounds.Dy())
+	artwork, err := s.repo.GetArtworkDetail(ctx, artID)
+	if err != nil {
+		return nil, err
+	}
 
-	return &width, &height, nil
+	return artwork.Images, nil
 }
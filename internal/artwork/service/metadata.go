@@ -0,0 +1,412 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/rwcarlsen/goexif/exif"
+	"golang.org/x/image/webp"
+)
+
+const reencodeJPEGQuality = 95
+
+var (
+	ErrMalformedJPEG = errors.New("malformed jpeg")
+	ErrMalformedWebP = errors.New("malformed webp")
+)
+
+type PreparedImage struct {
+	Image       image.Image
+	Content     []byte
+	Format      string
+	ContentType string
+}
+
+type exifMetadata struct {
+	orientation int
+	artist      string
+	copyright   string
+}
+
+func (s *ImageService) PrepareImage(file io.ReadSeeker, contentType, fileName string) (*PreparedImage, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	format := sniffImageFormat(data)
+	if format == "" || format == formatHEIC {
+		return nil, ErrUnsupportedFormat
+	}
+	if err := checkDeclaredFormat(format, contentType, fileName); err != nil {
+		return nil, err
+	}
+	if embeddedMarkup.Match(data) {
+		return nil, ErrPolyglotImage
+	}
+
+	prepared := &PreparedImage{Content: data, Format: format, ContentType: "image/" + format}
+
+	switch format {
+	case formatJPEG:
+		err = s.prepareJPEG(prepared)
+	case formatPNG:
+		err = preparePNG(prepared)
+	case formatGIF:
+		err = prepareGIF(prepared)
+	case formatWebP:
+		err = prepareWebP(prepared)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return prepared, nil
+}
+
+func (s *ImageService) prepareJPEG(prepared *PreparedImage) error {
+	img, err := jpeg.Decode(bytes.NewReader(prepared.Content))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	prepared.Image = img
+
+	meta := readExifMetadata(prepared.Content)
+
+	var keep []byte
+	if s.env.ExifKeepCopyright {
+		keep = buildCopyrightExif(meta.artist, meta.copyright)
+	}
+
+	// Anything after the EOI marker (MPF frames, phone trailers such as SEFT
+	// or motion-photo video) is dropped rather than rejected.
+	stripped, _, err := stripJPEGMetadata(prepared.Content, keep)
+	if err != nil {
+		return err
+	}
+
+	if meta.orientation > 1 && meta.orientation <= 8 {
+		prepared.Image = applyOrientation(prepared.Image, meta.orientation)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, prepared.Image, &jpeg.Options{Quality: reencodeJPEGQuality}); err != nil {
+			return err
+		}
+
+		stripped, _, err = stripJPEGMetadata(buf.Bytes(), keep)
+		if err != nil {
+			return err
+		}
+	}
+
+	prepared.Content = stripped
+	return nil
+}
+
+func preparePNG(prepared *PreparedImage) error {
+	r := bytes.NewReader(prepared.Content)
+	img, err := png.Decode(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	prepared.Image = img
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	prepared.Content = buf.Bytes()
+	return nil
+}
+
+func prepareGIF(prepared *PreparedImage) error {
+	r := bytes.NewReader(prepared.Content)
+	decoded, err := gif.DecodeAll(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	if len(decoded.Image) == 0 {
+		return ErrUnsupportedFormat
+	}
+
+	prepared.Image = decoded.Image[0]
+	prepared.Content = prepared.Content[:len(prepared.Content)-r.Len()]
+	return nil
+}
+
+func prepareWebP(prepared *PreparedImage) error {
+	img, err := webp.Decode(bytes.NewReader(prepared.Content))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	prepared.Image = img
+
+	stripped, err := stripWebPMetadata(prepared.Content)
+	if err != nil {
+		return err
+	}
+	prepared.Content = stripped
+	return nil
+}
+
+func readExifMetadata(data []byte) exifMetadata {
+	meta := exifMetadata{orientation: 1}
+
+	x, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return meta
+	}
+
+	if tag, err := x.Get(exif.Orientation); err == nil {
+		if orientation, err := tag.Int(0); err == nil {
+			meta.orientation = orientation
+		}
+	}
+	if tag, err := x.Get(exif.Artist); err == nil {
+		meta.artist, _ = tag.StringVal()
+	}
+	if tag, err := x.Get(exif.Copyright); err == nil {
+		meta.copyright, _ = tag.StringVal()
+	}
+
+	return meta
+}
+
+func applyOrientation(img image.Image, orientation int) image.Image {
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			default:
+				sx, sy = x, y
+			}
+
+			si := src.PixOffset(sx, sy)
+			di := dst.PixOffset(x, y)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
+
+func stripJPEGMetadata(data []byte, extra []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, ErrMalformedJPEG
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:2])
+	out.Write(extra)
+
+	i := 2
+	for {
+		if i+2 > len(data) || data[i] != 0xFF {
+			return nil, 0, ErrMalformedJPEG
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			i++
+			continue
+		case marker == 0xD9:
+			out.Write(data[i : i+2])
+			return out.Bytes(), i + 2, nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, 0, ErrMalformedJPEG
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, 0, ErrMalformedJPEG
+		}
+
+		if !isMetadataSegment(marker, data[i+4:end]) {
+			out.Write(data[i:end])
+		}
+		i = end
+
+		if marker == 0xDA {
+			scanEnd := skipEntropyData(data, i)
+			out.Write(data[i:scanEnd])
+			i = scanEnd
+		}
+	}
+}
+
+func skipEntropyData(data []byte, i int) int {
+	for i+1 < len(data) {
+		if data[i] != 0xFF {
+			i++
+			continue
+		}
+
+		next := data[i+1]
+		switch {
+		case next == 0x00 || (next >= 0xD0 && next <= 0xD7):
+			i += 2
+		case next == 0xFF:
+			i++
+		default:
+			return i
+		}
+	}
+	return len(data)
+}
+
+func isMetadataSegment(marker byte, payload []byte) bool {
+	switch marker {
+	case 0xE1, 0xED, 0xFE:
+		return true
+	case 0xE2:
+		return bytes.HasPrefix(payload, []byte("MPF\x00"))
+	default:
+		return false
+	}
+}
+
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 {
+		return nil, ErrMalformedWebP
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[4:8]))
+	end := 8 + size
+	if size < 4 || end > len(data) {
+		return nil, ErrMalformedWebP
+	}
+
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+
+	i := 12
+	for i < end {
+		if i+8 > end {
+			return nil, ErrMalformedWebP
+		}
+
+		fourCC := string(data[i : i+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		chunkEnd := i + 8 + chunkSize + chunkSize%2
+		if chunkEnd > end {
+			return nil, ErrMalformedWebP
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := bytes.Clone(data[i:chunkEnd])
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x0C
+			}
+			body.Write(chunk)
+		default:
+			body.Write(data[i:chunkEnd])
+		}
+
+		i = chunkEnd
+	}
+
+	out := make([]byte, 8, 8+body.Len())
+	copy(out, "RIFF")
+	binary.LittleEndian.PutUint32(out[4:8], uint32(body.Len()))
+	return append(out, body.Bytes()...), nil
+}
+
+func buildCopyrightExif(artist, copyright string) []byte {
+	type entry struct {
+		tag   uint16
+		value string
+	}
+
+	entries := []entry{}
+	if artist != "" {
+		entries = append(entries, entry{tag: 0x013B, value: artist})
+	}
+	if copyright != "" {
+		entries = append(entries, entry{tag: 0x8298, value: copyright})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	order := binary.LittleEndian
+	ifdSize := 2 + len(entries)*12 + 4
+	dataOffset := 8 + ifdSize
+
+	var tiff bytes.Buffer
+	tiff.Write([]byte{'I', 'I', 42, 0})
+	binary.Write(&tiff, order, uint32(8))
+	binary.Write(&tiff, order, uint16(len(entries)))
+
+	var values bytes.Buffer
+	for _, e := range entries {
+		value := append([]byte(e.value), 0)
+		binary.Write(&tiff, order, e.tag)
+		binary.Write(&tiff, order, uint16(2))
+		binary.Write(&tiff, order, uint32(len(value)))
+
+		if len(value) <= 4 {
+			inline := make([]byte, 4)
+			copy(inline, value)
+			tiff.Write(inline)
+			continue
+		}
+
+		binary.Write(&tiff, order, uint32(dataOffset+values.Len()))
+		values.Write(value)
+	}
+	binary.Write(&tiff, order, uint32(0))
+	tiff.Write(values.Bytes())
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"log"
+	"math"
+	"os"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+)
+
+const (
+	mockupPrefix       = "mockups/"
+	mockupMaxWidth     = 1600
+	mockupShadowInches = 0.4
+)
+
+var (
+	ErrMockupUnavailable = errors.New("mockup template not configured")
+	ErrNoArtworkImage    = errors.New("artwork has no image")
+)
+
+type mockupTemplate struct {
+	room          image.Image
+	pixelsPerInch float64
+	centerX       float64
+	centerY       float64
+	digest        string
+}
+
+func newMockupTemplate(env *config.Config) (*mockupTemplate, error) {
+	if env.MockupTemplate == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(env.MockupTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	room, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.New()
+	sum.Write(data)
+	fmt.Fprintf(sum, "|%g|%g|%g", env.MockupPixelsPerInch, env.MockupCenterX, env.MockupCenterY)
+
+	return &mockupTemplate{
+		room:          room,
+		pixelsPerInch: env.MockupPixelsPerInch,
+		centerX:       env.MockupCenterX,
+		centerY:       env.MockupCenterY,
+		digest:        hex.EncodeToString(sum.Sum(nil)),
+	}, nil
+}
+
+func (s *ArtworkService) Mockup(ctx context.Context, id uuid.UUID) (*RenderedImage, error) {
+	if s.mockup == nil {
+		return nil, ErrMockupUnavailable
+	}
+
+	artwork, err := s.repo.GetArtworkDetail(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if artwork == nil {
+		return nil, ErrArtworkNotFound
+	}
+
+	main := mainImage(artwork.Images)
+	if main == nil {
+		return nil, ErrNoArtworkImage
+	}
+
+	source := mockupSource(main)
+	if source == "" {
+		return nil, ErrNoArtworkImage
+	}
+
+	provider := s.imageService.provider
+	objectName := s.mockup.objectName(artwork, source)
+	if content, err := readObject(ctx, provider, objectName); err == nil {
+		return newRenderedImage(content, "image/jpeg"), nil
+	} else if !errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, err
+	}
+
+	rc, err := provider.GetObject(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	art, _, err := image.Decode(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	composite := s.mockup.render(art, artwork.WidthInches, artwork.HeightInches, main.ImageWidth, main.ImageHeight)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, composite, &jpeg.Options{Quality: variantJPEGQuality}); err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+	if err := provider.UploadObject(ctx, objectName, "image/jpeg", bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+
+	return newRenderedImage(content, "image/jpeg"), nil
+}
+
+// mockupSource is the object a mockup is rendered from: the largest variant,
+// which is watermarked and public, or the original for legacy public images
+// stored without variants.
+func mockupSource(main *domain.Image) string {
+	if largest := main.LargestVariant(); largest != nil {
+		return largest.ObjectName
+	}
+	if storage.IsPrivateObject(main.ObjectName) {
+		return ""
+	}
+	return main.ObjectName
+}
+
+func (t *mockupTemplate) objectName(artwork *domain.Artwork, source string) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%s|%g|%g", t.digest, source, artwork.WidthInches, artwork.HeightInches))
+	return fmt.Sprintf("%s%s/%s.jpg", mockupPrefix, artwork.ID, hex.EncodeToString(sum[:16]))
+}
+
+func (s *ArtworkService) purgeMockups(ctx context.Context, id uuid.UUID) {
+	objects, err := s.imageService.provider.ListObjects(ctx, mockupPrefix+id.String()+"/")
+	if err != nil {
+		log.Printf("failed to list mockups for %s: %v", id, err)
+		return
+	}
+
+	for _, object := range objects {
+		if err := s.imageService.provider.DeleteObject(ctx, object.Name); err != nil {
+			log.Printf("failed to delete mockup %s: %v", object.Name, err)
+		}
+	}
+}
+
+func (t *mockupTemplate) render(art image.Image, widthInches, heightInches float64, imageWidth, imageHeight *int32) image.Image {
+	roomBounds := t.room.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, roomBounds.Dx(), roomBounds.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), t.room, roomBounds.Min, draw.Src)
+
+	artWidth, artHeight := float64(art.Bounds().Dx()), float64(art.Bounds().Dy())
+	if imageWidth != nil && imageHeight != nil && *imageWidth > 0 && *imageHeight > 0 {
+		artWidth, artHeight = float64(*imageWidth), float64(*imageHeight)
+	}
+
+	width, height := fitImage(artWidth, artHeight, widthInches*t.pixelsPerInch, heightInches*t.pixelsPerInch)
+	centerX := float64(canvas.Bounds().Dx()) * t.centerX
+	centerY := float64(canvas.Bounds().Dy()) * t.centerY
+
+	target := image.Rect(
+		int(math.Round(centerX-width/2)),
+		int(math.Round(centerY-height/2)),
+		int(math.Round(centerX+width/2)),
+		int(math.Round(centerY+height/2)),
+	)
+
+	offset := max(1, int(math.Round(mockupShadowInches*t.pixelsPerInch)))
+	shadow := image.NewUniform(color.RGBA{A: 70})
+	draw.Draw(canvas, target.Add(image.Pt(offset/2, offset)), shadow, image.Point{}, draw.Over)
+	draw.CatmullRom.Scale(canvas, target, art, art.Bounds(), draw.Over, nil)
+
+	if canvas.Bounds().Dx() > mockupMaxWidth {
+		return resizeToWidth(canvas, mockupMaxWidth)
+	}
+	return canvas
+}
+
+func mainImage(images []domain.Image) *domain.Image {
+	for i := range images {
+		if images[i].IsMainImage {
+			return &images[i]
+		}
+	}
+	if len(images) > 0 {
+		return &images[0]
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidReorder = errors.New("invalid reorder request")
+)
+
+func (s *ArtworkService) Reorder(ctx context.Context, payload *domain.ReorderPayload) ([]domain.ArtworkSortOrder, error) {
+	if err := validateReorderPayload(payload); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReorderArtworks(ctx, func(current []domain.ArtworkSortOrder) ([]domain.ArtworkSortOrder, error) {
+		if payload.Move != nil {
+			return applyMove(current, payload.Move)
+		}
+		return applyOrderedIDs(current, payload.IDs)
+	})
+}
+
+func validateReorderPayload(payload *domain.ReorderPayload) error {
+	hasIDs := len(payload.IDs) > 0
+	hasMove := payload.Move != nil
+	if hasIDs == hasMove {
+		return ErrInvalidReorder
+	}
+
+	if hasMove {
+		move := payload.Move
+		if (move.Before == nil) == (move.After == nil) {
+			return ErrInvalidReorder
+		}
+		if anchor := moveAnchor(move); *anchor == move.ID {
+			return ErrInvalidReorder
+		}
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(payload.IDs))
+	for _, id := range payload.IDs {
+		if seen[id] {
+			return ErrInvalidReorder
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+func applyOrderedIDs(current []domain.ArtworkSortOrder, ids []uuid.UUID) ([]domain.ArtworkSortOrder, error) {
+	listed := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		listed[id] = true
+	}
+
+	slots := make([]int, 0, len(ids))
+	for i, item := range current {
+		if listed[item.ID] {
+			slots = append(slots, i)
+		}
+	}
+	if len(slots) != len(ids) {
+		return nil, ErrArtworkNotFound
+	}
+
+	sequence := make([]uuid.UUID, len(current))
+	for i, item := range current {
+		sequence[i] = item.ID
+	}
+	for i, slot := range slots {
+		sequence[slot] = ids[i]
+	}
+
+	return renumber(current, sequence), nil
+}
+
+func applyMove(current []domain.ArtworkSortOrder, move *domain.MovePayload) ([]domain.ArtworkSortOrder, error) {
+	anchor := *moveAnchor(move)
+
+	var found bool
+	rest := make([]domain.ArtworkSortOrder, 0, len(current))
+	for _, item := range current {
+		if item.ID == move.ID {
+			found = true
+			continue
+		}
+		rest = append(rest, item)
+	}
+
+	anchorIdx := slices.IndexFunc(rest, func(item domain.ArtworkSortOrder) bool { return item.ID == anchor })
+	if !found || anchorIdx < 0 {
+		return nil, ErrArtworkNotFound
+	}
+
+	insertAt := anchorIdx
+	if move.After != nil {
+		insertAt = anchorIdx + 1
+	}
+
+	if sortOrder, ok := sortOrderBetween(rest, insertAt); ok {
+		return []domain.ArtworkSortOrder{{ID: move.ID, SortOrder: sortOrder}}, nil
+	}
+
+	sequence := make([]uuid.UUID, 0, len(current))
+	for _, item := range rest[:insertAt] {
+		sequence = append(sequence, item.ID)
+	}
+	sequence = append(sequence, move.ID)
+	for _, item := range rest[insertAt:] {
+		sequence = append(sequence, item.ID)
+	}
+
+	return renumber(current, sequence), nil
+}
+
+func moveAnchor(move *domain.MovePayload) *uuid.UUID {
+	if move.Before != nil {
+		return move.Before
+	}
+	return move.After
+}
+
+func sortOrderBetween(items []domain.ArtworkSortOrder, insertAt int) (int32, bool) {
+	var value int64
+
+	switch {
+	case len(items) == 0:
+		value = domain.SortOrderGap
+	case insertAt == 0:
+		value = int64(items[0].SortOrder) - domain.SortOrderGap
+	case insertAt == len(items):
+		value = int64(items[len(items)-1].SortOrder) + domain.SortOrderGap
+	default:
+		prev := int64(items[insertAt-1].SortOrder)
+		next := int64(items[insertAt].SortOrder)
+		if next-prev < 2 {
+			return 0, false
+		}
+		value = prev + (next-prev)/2
+	}
+
+	if value < math.MinInt32 || value > math.MaxInt32 {
+		return 0, false
+	}
+
+	return int32(value), true
+}
+
+func renumber(current []domain.ArtworkSortOrder, sequence []uuid.UUID) []domain.ArtworkSortOrder {
+	previous := make(map[uuid.UUID]int32, len(current))
+	for _, item := range current {
+		previous[item.ID] = item.SortOrder
+	}
+
+	updated := []domain.ArtworkSortOrder{}
+	for i, id := range sequence {
+		sortOrder := int32((i + 1) * domain.SortOrderGap)
+		if previous[id] != sortOrder {
+			updated = append(updated, domain.ArtworkSortOrder{ID: id, SortOrder: sortOrder})
+		}
+	}
+
+	return updated
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+)
+
+const (
+	paletteSampleWidth     = 64
+	paletteSize            = 5
+	paletteIterations      = 12
+	colorMatchDistance     = 20.0
+	colorMatchMinWeight    = 0.05
+	paletteWeightPrecision = 1000
+)
+
+var (
+	ErrInvalidColor = errors.New("invalid color")
+)
+
+type labColor struct {
+	l, a, b float64
+}
+
+type paletteCluster struct {
+	center  labColor
+	sum     labColor
+	r, g, b float64
+	count   int
+}
+
+func extractPalette(img image.Image) []domain.PaletteColor {
+	sample := resizeToWidth(img, min(paletteSampleWidth, img.Bounds().Dx()))
+
+	bounds := sample.Bounds()
+	pixels := make([]labColor, 0, bounds.Dx()*bounds.Dy())
+	rgb := make([][3]uint8, 0, cap(pixels))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := sample.RGBAAt(x, y)
+			pixels = append(pixels, rgbToLab(c.R, c.G, c.B))
+			rgb = append(rgb, [3]uint8{c.R, c.G, c.B})
+		}
+	}
+
+	clusters := initialClusters(pixels, min(paletteSize, len(pixels)))
+	assignments := make([]int, len(pixels))
+
+	for range paletteIterations {
+		for i := range clusters {
+			clusters[i] = paletteCluster{center: clusters[i].center}
+		}
+
+		for i, pixel := range pixels {
+			nearest := 0
+			for j := range clusters {
+				if deltaE(pixel, clusters[j].center) < deltaE(pixel, clusters[nearest].center) {
+					nearest = j
+				}
+			}
+			assignments[i] = nearest
+
+			cluster := &clusters[nearest]
+			cluster.sum.l += pixel.l
+			cluster.sum.a += pixel.a
+			cluster.sum.b += pixel.b
+			cluster.r += float64(rgb[i][0])
+			cluster.g += float64(rgb[i][1])
+			cluster.b += float64(rgb[i][2])
+			cluster.count++
+		}
+
+		for i := range clusters {
+			if n := float64(clusters[i].count); n > 0 {
+				clusters[i].center = labColor{clusters[i].sum.l / n, clusters[i].sum.a / n, clusters[i].sum.b / n}
+			}
+		}
+	}
+
+	palette := []domain.PaletteColor{}
+	for _, cluster := range clusters {
+		if cluster.count == 0 {
+			continue
+		}
+
+		n := float64(cluster.count)
+		palette = append(palette, domain.PaletteColor{
+			Color:  fmt.Sprintf("#%02x%02x%02x", uint8(math.Round(cluster.r/n)), uint8(math.Round(cluster.g/n)), uint8(math.Round(cluster.b/n))),
+			Weight: math.Round(n/float64(len(pixels))*paletteWeightPrecision) / paletteWeightPrecision,
+		})
+	}
+
+	slices.SortStableFunc(palette, func(a, b domain.PaletteColor) int {
+		switch {
+		case a.Weight > b.Weight:
+			return -1
+		case a.Weight < b.Weight:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return palette
+}
+
+func initialClusters(pixels []labColor, k int) []paletteCluster {
+	clusters := make([]paletteCluster, 0, k)
+	if k == 0 {
+		return clusters
+	}
+
+	clusters = append(clusters, paletteCluster{center: pixels[0]})
+	for len(clusters) < k {
+		farthest, distance := 0, -1.0
+		for i, pixel := range pixels {
+			nearest := math.MaxFloat64
+			for _, cluster := range clusters {
+				nearest = min(nearest, deltaE(pixel, cluster.center))
+			}
+			if nearest > distance {
+				farthest, distance = i, nearest
+			}
+		}
+		clusters = append(clusters, paletteCluster{center: pixels[farthest]})
+	}
+
+	return clusters
+}
+
+func paletteMatches(palette []domain.PaletteColor, target labColor) bool {
+	for _, entry := range palette {
+		if entry.Weight < colorMatchMinWeight {
+			continue
+		}
+
+		color, err := parseHexColor(entry.Color)
+		if err != nil {
+			continue
+		}
+		if deltaE(color, target) <= colorMatchDistance {
+			return true
+		}
+	}
+	return false
+}
+
+func parseHexColor(value string) (labColor, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(value) != 6 {
+		return labColor{}, ErrInvalidColor
+	}
+
+	parsed, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return labColor{}, ErrInvalidColor
+	}
+
+	return rgbToLab(uint8(parsed>>16), uint8(parsed>>8), uint8(parsed)), nil
+}
+
+func rgbToLab(r, g, b uint8) labColor {
+	lr, lg, lb := srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+
+	x := (0.4124564*lr + 0.3575761*lg + 0.1804375*lb) / 0.95047
+	y := 0.2126729*lr + 0.7151522*lg + 0.0721750*lb
+	z := (0.0193339*lr + 0.1191920*lg + 0.9503041*lb) / 1.08883
+
+	fx, fy, fz := labF(x), labF(y), labF(z)
+	return labColor{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func labF(t float64) float64 {
+	if t > 216.0/24389.0 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27.0*t + 16) / 116
+}
+
+func deltaE(x, y labColor) float64 {
+	dl, da, db := x.l-y.l, x.a-y.a, x.b-y.b
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"image"
+	"log"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+)
+
+const (
+	phashWidth  = phashHeight + 1
+	phashHeight = 8
+
+	DuplicateHashDistance  = 10
+	MaxSimilarHashDistance = 32
+)
+
+var (
+	ErrInvalidDistance = errors.New("invalid hamming distance")
+)
+
+func perceptualHash(img image.Image) int64 {
+	sample := image.NewGray(image.Rect(0, 0, phashWidth, phashHeight))
+	draw.BiLinear.Scale(sample, sample.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var hash uint64
+	for y := 0; y < phashHeight; y++ {
+		for x := 0; x < phashWidth-1; x++ {
+			hash <<= 1
+			if sample.GrayAt(x, y).Y < sample.GrayAt(x+1, y).Y {
+				hash |= 1
+			}
+		}
+	}
+
+	return int64(hash)
+}
+
+func (s *ImageService) findDuplicates(ctx context.Context, image *domain.Image) []domain.SimilarImage {
+	if image.PHash == nil {
+		return nil
+	}
+
+	duplicates, err := s.repo.ListSimilarImages(ctx, image.ID, *image.PHash, DuplicateHashDistance)
+	if err != nil {
+		log.Printf("duplicate check for image %s failed: %v", image.ID, err)
+		return nil
+	}
+
+	return duplicates
+}
+
+func (s *ImageService) FindSimilar(ctx context.Context, artID, id uuid.UUID, maxDistance int) ([]domain.SimilarImage, error) {
+	if maxDistance < 0 || maxDistance > MaxSimilarHashDistance {
+		return nil, ErrInvalidDistance
+	}
+
+	img, err := s.repo.GetImageDetail(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if img.ArtworkID != artID {
+		return nil, ErrInvalidArtID
+	}
+
+	if img.PHash == nil {
+		hash, err := s.hashStoredImage(ctx, img)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.UpdateImagePHash(ctx, id, hash); err != nil {
+			return nil, err
+		}
+		img.PHash = &hash
+	}
+
+	return s.repo.ListSimilarImages(ctx, id, *img.PHash, int32(maxDistance))
+}
+
+func (s *ImageService) hashStoredImage(ctx context.Context, img *domain.Image) (int64, error) {
+	rc, err := s.provider.GetObject(ctx, img.ObjectName)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	decoded, _, err := image.Decode(rc)
+	if err != nil {
+		return 0, err
+	}
+
+	return perceptualHash(decoded), nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/buckket/go-blurhash"
+)
+
+const (
+	placeholderSampleWidth = 64
+	blurHashXComponents    = 4
+	blurHashYComponents    = 3
+)
+
+func computePlaceholders(img image.Image) (*string, *string, error) {
+	sample := resizeToWidth(img, min(placeholderSampleWidth, img.Bounds().Dx()))
+
+	hash, err := blurhash.Encode(blurHashXComponents, blurHashYComponents, sample)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	color := dominantColor(sample)
+	return &hash, &color, nil
+}
+
+func dominantColor(img *image.RGBA) string {
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+
+	buckets := map[int]*bucket{}
+	var best *bucket
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			key := int(c.R>>4)<<8 | int(c.G>>4)<<4 | int(c.B>>4)
+
+			b, ok := buckets[key]
+			if !ok {
+				b = &bucket{}
+				buckets[key] = b
+			}
+			b.count++
+			b.r += int(c.R)
+			b.g += int(c.G)
+			b.b += int(c.B)
+
+			if best == nil || b.count > best.count {
+				best = b
+			}
+		}
+	}
+
+	if best == nil {
+		return "#000000"
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.count, best.g/best.count, best.b/best.count)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const frontendPreviewEndpoint = "/preview"
+
+var (
+	ErrInvalidPreview  = errors.New("invalid preview request")
+	ErrPreviewNotFound = errors.New("preview not found")
+)
+
+type PreviewService struct {
+	repo   repo.Repo
+	config *config.Config
+}
+
+func NewPreviewService(repo repo.Repo, config *config.Config) *PreviewService {
+	return &PreviewService{repo: repo, config: config}
+}
+
+func (s *PreviewService) Create(ctx context.Context, payload *domain.PreviewPayload) (*domain.PreviewLinkWithToken, error) {
+	if len(payload.ArtworkIDs) == 0 || payload.ExpiresInHours < 0 {
+		return nil, ErrInvalidPreview
+	}
+
+	expiration := domain.DefaultPreviewExpiration
+	if payload.ExpiresInHours > 0 {
+		expiration = min(time.Duration(payload.ExpiresInHours)*time.Hour, domain.MaxPreviewExpiration)
+	}
+
+	artworks, err := s.repo.GetArtworksByIDs(ctx, payload.ArtworkIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(artworks) != len(payload.ArtworkIDs) {
+		return nil, ErrArtworkNotFound
+	}
+
+	expiresAt := time.Now().Add(expiration)
+	link, err := s.repo.CreatePreviewLink(ctx, payload.ArtworkIDs, payload.Label, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.CreatePreviewToken(link.ID, link.ExpiresAt, s.config.JwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PreviewLinkWithToken{
+		PreviewLink: *link,
+		Token:       token,
+		URL:         fmt.Sprintf("%s%s/%s", s.config.FrontendUrl, frontendPreviewEndpoint, token),
+	}, nil
+}
+
+func (s *PreviewService) List(ctx context.Context) ([]domain.PreviewLink, error) {
+	return s.repo.ListPreviewLinks(ctx)
+}
+
+func (s *PreviewService) Revoke(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.RevokePreviewLink(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return ErrPreviewNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *PreviewService) View(ctx context.Context, token string) (*domain.Preview, error) {
+	claims, err := utils.ParsePreviewToken(token, s.config.JwtSecret)
+	if err != nil {
+		return nil, ErrPreviewNotFound
+	}
+
+	link, err := s.repo.RecordPreviewLinkView(ctx, claims.LinkID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPreviewNotFound
+		}
+		return nil, err
+	}
+
+	artworks, err := s.repo.GetArtworksByIDs(ctx, link.ArtworkIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range artworks {
+		artworks[i].Measurements = domain.NewMeasurements(&artworks[i], domain.MeasurementSystemImperial)
+	}
+	hidePrivateOriginals(artworks)
+
+	return &domain.Preview{
+		Label:     link.Label,
+		ExpiresAt: link.ExpiresAt,
+		Artworks:  artworks,
+	}, nil
+}
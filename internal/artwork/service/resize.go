@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const resizedPrefix = "resized/"
+
+var resizeWidths = map[int]bool{
+	160:  false,
+	320:  false,
+	480:  true,
+	640:  true,
+	800:  true,
+	1200: true,
+	1600: true,
+	2400: true,
+}
+
+var resizeFormats = map[string]string{
+	formatJPEG: "image/jpeg",
+	formatPNG:  "image/png",
+}
+
+var (
+	ErrInvalidWidth  = errors.New("width not allowed")
+	ErrInvalidFormat = errors.New("format not allowed")
+	ErrImageNotFound = errors.New("image not found")
+)
+
+type RenderedImage struct {
+	Content     []byte
+	ContentType string
+	ETag        string
+}
+
+func (s *ImageService) Resize(ctx context.Context, id uuid.UUID, width int, format string) (*RenderedImage, error) {
+	watermarked, ok := resizeWidths[width]
+	if !ok {
+		return nil, ErrInvalidWidth
+	}
+	contentType, ok := resizeFormats[format]
+	if !ok {
+		return nil, ErrInvalidFormat
+	}
+	if s.env.PrivateOriginals {
+		if err := checkPrivateRendition(width, format); err != nil {
+			return nil, err
+		}
+	}
+
+	objectName := resizedObjectName(id, width, format)
+	if content, err := readObject(ctx, s.provider, objectName); err == nil {
+		return newRenderedImage(content, contentType), nil
+	} else if !errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, err
+	}
+
+	img, err := s.repo.GetImageDetail(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, err
+	}
+	if storage.IsPrivateObject(img.ObjectName) {
+		if err := checkPrivateRendition(width, format); err != nil {
+			return nil, err
+		}
+	}
+
+	original, err := s.provider.GetObject(ctx, img.ObjectName)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, ErrImageNotFound
+		}
+		return nil, err
+	}
+	defer original.Close()
+
+	decoded, _, err := image.Decode(original)
+	if err != nil {
+		return nil, err
+	}
+
+	resized := resizeToWidth(decoded, min(width, decoded.Bounds().Dx()))
+	if watermarked && s.watermark != nil {
+		if err := s.watermark.apply(resized); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case formatPNG:
+		err = png.Encode(&buf, resized)
+	default:
+		err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: variantJPEGQuality})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+	if err := s.provider.UploadObject(ctx, objectName, contentType, bytes.NewReader(content)); err != nil {
+		log.Printf("failed to cache resized image %s: %v", objectName, err)
+	}
+
+	return newRenderedImage(content, contentType), nil
+}
+
+// checkPrivateRendition keeps /img from handing out more than the public
+// variants do when originals are private: JPEG only, no wider than the
+// largest variant.
+func checkPrivateRendition(width int, format string) error {
+	if width > maxPublicWidth {
+		return ErrInvalidWidth
+	}
+	if format != formatJPEG {
+		return ErrInvalidFormat
+	}
+	return nil
+}
+
+func (s *ImageService) purgeResized(ctx context.Context, id uuid.UUID) {
+	objects, err := s.provider.ListObjects(ctx, resizedPrefix+id.String()+"/")
+	if err != nil {
+		log.Printf("failed to list resized images for %s: %v", id, err)
+		return
+	}
+
+	for _, object := range objects {
+		if err := s.provider.DeleteObject(ctx, object.Name); err != nil {
+			log.Printf("failed to delete resized image %s: %v", object.Name, err)
+		}
+	}
+}
+
+func resizedObjectName(id uuid.UUID, width int, format string) string {
+	return fmt.Sprintf("%s%s/%d%s", resizedPrefix, id, width, canonicalExtensions[format])
+}
+
+func readObject(ctx context.Context, provider storage.Provider, objectName string) ([]byte, error) {
+	rc, err := provider.GetObject(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+func newRenderedImage(content []byte, contentType string) *RenderedImage {
+	sum := sha256.Sum256(content)
+	return &RenderedImage{
+		Content:     content,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+}
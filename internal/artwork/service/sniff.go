@@ -0,0 +1,100 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	formatJPEG = "jpeg"
+	formatPNG  = "png"
+	formatGIF  = "gif"
+	formatWebP = "webp"
+	formatHEIC = "heic"
+)
+
+var (
+	ErrContentMismatch = errors.New("file content does not match declared type")
+	ErrPolyglotImage   = errors.New("file contains non-image data")
+)
+
+var declaredImageTypes = map[string]string{
+	"image/jpeg":  formatJPEG,
+	"image/jpg":   formatJPEG,
+	"image/pjpeg": formatJPEG,
+	"image/png":   formatPNG,
+	"image/gif":   formatGIF,
+	"image/webp":  formatWebP,
+	"image/heic":  formatHEIC,
+	"image/heif":  formatHEIC,
+}
+
+var imageExtensions = map[string]string{
+	".jpg":  formatJPEG,
+	".jpeg": formatJPEG,
+	".jpe":  formatJPEG,
+	".jfif": formatJPEG,
+	".png":  formatPNG,
+	".gif":  formatGIF,
+	".webp": formatWebP,
+	".heic": formatHEIC,
+	".heif": formatHEIC,
+}
+
+var canonicalExtensions = map[string]string{
+	formatJPEG: ".jpg",
+	formatPNG:  ".png",
+	formatGIF:  ".gif",
+	formatWebP: ".webp",
+}
+
+var heicBrands = []string{"heic", "heix", "hevc", "hevx", "heim", "heis", "mif1", "msf1"}
+
+var embeddedMarkup = regexp.MustCompile(`(?i)<(script|html|body|iframe|svg|!doctype|\?php)[\s>/]`)
+
+func sniffImageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return formatJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return formatPNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return formatGIF
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return formatWebP
+	case len(data) >= 12 && string(data[4:8]) == "ftyp":
+		for _, brand := range heicBrands {
+			if string(data[8:12]) == brand {
+				return formatHEIC
+			}
+		}
+	}
+	return ""
+}
+
+func checkDeclaredFormat(format, contentType, fileName string) error {
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return ErrContentMismatch
+		}
+		if mediaType != "application/octet-stream" && declaredImageTypes[mediaType] != format {
+			return ErrContentMismatch
+		}
+	}
+
+	if ext := strings.ToLower(filepath.Ext(fileName)); ext != "" && imageExtensions[ext] != format {
+		return ErrContentMismatch
+	}
+
+	return nil
+}
+
+func CanonicalFileName(fileName, format string) string {
+	base := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	return base + canonicalExtensions[format]
+}
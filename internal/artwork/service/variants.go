@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"log"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"golang.org/x/image/draw"
+)
+
+const variantJPEGQuality = 82
+
+type variantSpec struct {
+	name      string
+	width     int
+	watermark bool
+}
+
+var imageVariantSpecs = []variantSpec{
+	{name: "thumbnail", width: 320},
+	{name: "medium", width: 800, watermark: true},
+	{name: "large", width: 1600, watermark: true},
+}
+
+// maxPublicWidth is the widest rendition served publicly for a private
+// original, matching the largest stored variant.
+var maxPublicWidth = imageVariantSpecs[len(imageVariantSpecs)-1].width
+
+func (s *ImageService) createVariants(ctx context.Context, img image.Image, objectName string) ([]domain.ImageVariant, error) {
+	variants := []domain.ImageVariant{}
+	private := storage.IsPrivateObject(objectName)
+
+	for _, spec := range imageVariantSpecs {
+		width, fullSize := spec.width, false
+		if width >= img.Bounds().Dx() {
+			// A private original is never served, so the first variant at or
+			// above its width becomes a full-size copy instead of being skipped.
+			if !private {
+				continue
+			}
+			width, fullSize = img.Bounds().Dx(), true
+		}
+
+		resized := resizeToWidth(img, width)
+		if spec.watermark && s.watermark != nil {
+			if err := s.watermark.apply(resized); err != nil {
+				return nil, err
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: variantJPEGQuality}); err != nil {
+			return nil, err
+		}
+
+		variantName := variantObjectName(objectName, spec.name, "jpg")
+		if err := s.provider.UploadObject(ctx, variantName, "image/jpeg", &buf); err != nil {
+			return nil, err
+		}
+
+		bounds := resized.Bounds()
+		variants = append(variants, domain.ImageVariant{
+			Name:        spec.name,
+			Format:      "jpeg",
+			ContentType: "image/jpeg",
+			Width:       int32(bounds.Dx()),
+			Height:      int32(bounds.Dy()),
+			ObjectName:  variantName,
+			URL:         s.provider.GetObjectURL(variantName),
+		})
+
+		if fullSize {
+			break
+		}
+	}
+
+	return variants, nil
+}
+
+func (s *ImageService) RegenerateVariants(ctx context.Context, img *domain.Image) (*domain.Image, error) {
+	rc, err := s.provider.GetObject(ctx, img.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	decoded, _, err := image.Decode(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(img.Palette) == 0 {
+		if err := s.repo.UpdateImagePalette(ctx, img.ID, extractPalette(decoded)); err != nil {
+			return nil, err
+		}
+	}
+
+	variants, err := s.createVariants(ctx, decoded, img.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateImageVariants(ctx, img.ID, variants)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]bool{}
+	for _, variant := range variants {
+		current[variant.ObjectName] = true
+	}
+	for _, variant := range img.Variants {
+		if current[variant.ObjectName] {
+			continue
+		}
+		if err := s.provider.DeleteObject(ctx, variant.ObjectName); err != nil {
+			log.Printf("failed to delete stale variant %s: %v", variant.ObjectName, err)
+		}
+	}
+	s.purgeResized(ctx, img.ID)
+
+	return updated, nil
+}
+
+func resizeToWidth(img image.Image, width int) *image.RGBA {
+	src := img.Bounds()
+	height := max(1, int(math.Round(float64(src.Dy())*float64(width)/float64(src.Dx()))))
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Over, nil)
+
+	return dst
+}
+
+func variantObjectName(objectName, name, ext string) string {
+	base := strings.TrimSuffix(strings.TrimPrefix(objectName, storage.PrivateObjectPrefix), path.Ext(objectName))
+	return base + "-" + name + "." + ext
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	watermarkMarginRatio   = 0.02
+	watermarkMeasureSize   = 100
+	watermarkShadowDivisor = 24
+)
+
+type watermark struct {
+	text     string
+	font     *opentype.Font
+	overlay  image.Image
+	position string
+	opacity  float64
+	scale    float64
+}
+
+func newWatermark(env *config.Config) (*watermark, error) {
+	if env.WatermarkText == "" && env.WatermarkImage == "" {
+		return nil, nil
+	}
+
+	mark := &watermark{
+		text:     env.WatermarkText,
+		position: env.WatermarkPosition,
+		opacity:  env.WatermarkOpacity,
+		scale:    env.WatermarkScale,
+	}
+
+	if env.WatermarkImage != "" {
+		file, err := os.Open(env.WatermarkImage)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		mark.overlay, err = png.Decode(file)
+		if err != nil {
+			return nil, err
+		}
+		return mark, nil
+	}
+
+	parsed, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, err
+	}
+	mark.font = parsed
+
+	return mark, nil
+}
+
+func (m *watermark) apply(dst *image.RGBA) error {
+	bounds := dst.Bounds()
+	width := max(1, int(math.Round(float64(bounds.Dx())*m.scale)))
+
+	var overlay image.Image
+	if m.overlay != nil {
+		overlay = resizeOverlay(m.overlay, width)
+	} else {
+		rendered, err := m.renderText(width)
+		if err != nil {
+			return err
+		}
+		overlay = rendered
+	}
+
+	margin := int(math.Round(float64(min(bounds.Dx(), bounds.Dy())) * watermarkMarginRatio))
+	origin := watermarkOrigin(m.position, bounds, overlay.Bounds(), margin)
+
+	mask := image.NewUniform(color.Alpha{A: uint8(math.Round(m.opacity * 255))})
+	target := image.Rectangle{Min: origin, Max: origin.Add(overlay.Bounds().Size())}
+	draw.DrawMask(dst, target, overlay, overlay.Bounds().Min, mask, image.Point{}, draw.Over)
+
+	return nil
+}
+
+func (m *watermark) renderText(width int) (*image.RGBA, error) {
+	measure, err := opentype.NewFace(m.font, &opentype.FaceOptions{Size: watermarkMeasureSize, DPI: 72})
+	if err != nil {
+		return nil, err
+	}
+	advance := font.MeasureString(measure, m.text).Round()
+	measure.Close()
+	if advance == 0 {
+		return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
+	}
+
+	size := watermarkMeasureSize * float64(width) / float64(advance)
+	face, err := opentype.NewFace(m.font, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	metrics := face.Metrics()
+	shadow := max(1, int(math.Round(size/watermarkShadowDivisor)))
+	textWidth := font.MeasureString(face, m.text).Ceil()
+	height := (metrics.Ascent + metrics.Descent).Ceil()
+
+	canvas := image.NewRGBA(image.Rect(0, 0, textWidth+shadow, height+shadow))
+	drawer := &font.Drawer{Dst: canvas, Face: face}
+
+	drawer.Src = image.NewUniform(color.RGBA{A: 160})
+	drawer.Dot = fixed.Point26_6{X: fixed.I(shadow), Y: metrics.Ascent + fixed.I(shadow)}
+	drawer.DrawString(m.text)
+
+	drawer.Src = image.White
+	drawer.Dot = fixed.Point26_6{X: 0, Y: metrics.Ascent}
+	drawer.DrawString(m.text)
+
+	return canvas, nil
+}
+
+func resizeOverlay(overlay image.Image, width int) *image.RGBA {
+	src := overlay.Bounds()
+	height := max(1, int(math.Round(float64(src.Dy())*float64(width)/float64(src.Dx()))))
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), overlay, src, draw.Over, nil)
+	return dst
+}
+
+func watermarkOrigin(position string, bounds, overlay image.Rectangle, margin int) image.Point {
+	left := bounds.Min.X + margin
+	top := bounds.Min.Y + margin
+	right := bounds.Max.X - margin - overlay.Dx()
+	bottom := bounds.Max.Y - margin - overlay.Dy()
+
+	switch position {
+	case "top-left":
+		return image.Pt(left, top)
+	case "top-right":
+		return image.Pt(right, top)
+	case "bottom-left":
+		return image.Pt(left, bottom)
+	case "center":
+		return image.Pt(bounds.Min.X+(bounds.Dx()-overlay.Dx())/2, bounds.Min.Y+(bounds.Dy()-overlay.Dy())/2)
+	default:
+		return image.Pt(right, bottom)
+	}
+}
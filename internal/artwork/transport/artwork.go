@@ -1,10 +1,12 @@
 package transport
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
 	"github.com/art-vbst/art-backend/internal/artwork/repo"
@@ -22,18 +24,28 @@ type ArtworkHandler struct {
 	env     *config.Config
 }
 
-func NewArtworkHandler(db *store.Store, provider storage.Provider, env *config.Config) *ArtworkHandler {
-	service := service.NewArtworkService(repo.New(db), provider)
-	return &ArtworkHandler{service: service, env: env}
+func NewArtworkHandler(db *store.Store, provider storage.Provider, env *config.Config) (*ArtworkHandler, error) {
+	service, err := service.NewArtworkService(repo.New(db), provider, env)
+	if err != nil {
+		return nil, err
+	}
+	return &ArtworkHandler{service: service, env: env}, nil
 }
 
 func (h *ArtworkHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 	r.Get("/", h.list)
 	r.Post("/", h.create)
+	r.Post("/reorder", h.reorder)
+	r.Get("/export/catalog", h.exportCatalog)
+	r.Post("/images/import", h.importImages)
 	r.Get("/{id}", h.detail)
 	r.Put("/{id}", h.update)
 	r.Delete("/{id}", h.delete)
+
+	limiter := utils.NewIPRateLimiter(60, time.Minute)
+	r.With(limiter.Middleware).Get("/{id}/mockup", h.mockup)
+
 	return r
 }
 
@@ -44,8 +56,18 @@ func (h *ArtworkHandler) list(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	artworks, err := h.service.List(r.Context(), statuses)
+	system, err := parseMeasurementSystem(r.URL.Query().Get("units"))
 	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid units provided")
+		return
+	}
+
+	artworks, err := h.service.List(r.Context(), statuses, system, r.URL.Query().Get("color"))
+	if err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
+	if err := h.service.ResolveImageURLs(r.Context(), artworks, isAdmin(r, h.env.JwtSecret)); err != nil {
 		handleArtworkServiceError(w, err)
 		return
 	}
@@ -81,15 +103,43 @@ func (h *ArtworkHandler) detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	artwork, err := h.service.Detail(r.Context(), id)
+	system, err := parseMeasurementSystem(r.URL.Query().Get("units"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid units provided")
+		return
+	}
+
+	artwork, err := h.service.Detail(r.Context(), id, system)
 	if err != nil {
 		handleArtworkServiceError(w, err)
 		return
 	}
+	if err := h.service.ResolveArtworkURLs(r.Context(), artwork, isAdmin(r, h.env.JwtSecret)); err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
 
 	utils.RespondJSON(w, http.StatusOK, artwork)
 }
 
+const mockupCacheControl = "public, max-age=86400"
+
+func (h *ArtworkHandler) mockup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid artwork id")
+		return
+	}
+
+	mockup, err := h.service.Mockup(r.Context(), id)
+	if err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
+
+	respondRenderedImage(w, r, mockup, mockupCacheControl)
+}
+
 func (h *ArtworkHandler) update(w http.ResponseWriter, r *http.Request) {
 	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
 		return
@@ -113,10 +163,125 @@ func (h *ArtworkHandler) update(w http.ResponseWriter, r *http.Request) {
 		handleArtworkServiceError(w, err)
 		return
 	}
+	if err := h.service.ResolveArtworkURLs(r.Context(), artwork, true); err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
 
 	utils.RespondJSON(w, http.StatusOK, artwork)
 }
 
+func (h *ArtworkHandler) reorder(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1*utils.MB)
+	var body domain.ReorderPayload
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid reorder request")
+		return
+	}
+
+	updated, err := h.service.Reorder(r.Context(), &body)
+	if err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, updated)
+}
+
+func (h *ArtworkHandler) exportCatalog(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	filter, err := parseCatalogExportFilter(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid export filter")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.service.ExportCatalog(r.Context(), filter, &buf); err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="catalogue-raisonne.pdf"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+const (
+	attrArchive          = "archive"
+	maxArchiveUploadSize = 512 * utils.MB
+)
+
+func (h *ArtworkHandler) importImages(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxArchiveUploadSize)
+	if err := r.ParseMultipartForm(10 * utils.MB); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid form data")
+		return
+	}
+
+	file, header, err := r.FormFile(attrArchive)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid form data")
+		return
+	}
+	defer file.Close()
+
+	report, err := h.service.ImportImages(r.Context(), file, header.Size)
+	if err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
+	if err := h.service.ResolveReportURLs(r.Context(), report); err != nil {
+		handleArtworkServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, report)
+}
+
+func parseCatalogExportFilter(r *http.Request) (*domain.CatalogExportFilter, error) {
+	query := r.URL.Query()
+
+	statuses, err := parseArtworkStatuses(query["status"])
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := parseArtworkCategories(query["category"])
+	if err != nil {
+		return nil, err
+	}
+
+	yearFrom, err := parseYear(query.Get("year_from"))
+	if err != nil {
+		return nil, err
+	}
+
+	yearTo, err := parseYear(query.Get("year_to"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CatalogExportFilter{
+		Statuses:   statuses,
+		Categories: categories,
+		YearFrom:   yearFrom,
+		YearTo:     yearTo,
+	}, nil
+}
+
 func (h *ArtworkHandler) delete(w http.ResponseWriter, r *http.Request) {
 	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
 		return
@@ -140,6 +305,18 @@ func handleArtworkServiceError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, service.ErrArtworkNotFound):
 		utils.RespondError(w, http.StatusNotFound, "Artwork not found")
+	case errors.Is(err, service.ErrDuplicatePaintingNumber):
+		utils.RespondError(w, http.StatusConflict, "painting number already in use")
+	case errors.Is(err, service.ErrInvalidReorder):
+		utils.RespondError(w, http.StatusBadRequest, "invalid reorder request")
+	case errors.Is(err, service.ErrInvalidArchive):
+		utils.RespondError(w, http.StatusBadRequest, "invalid zip archive")
+	case errors.Is(err, service.ErrInvalidColor):
+		utils.RespondError(w, http.StatusBadRequest, "invalid color, expected a hex value like #1f4e9c")
+	case errors.Is(err, service.ErrMockupUnavailable):
+		utils.RespondError(w, http.StatusNotFound, "mockups are not configured")
+	case errors.Is(err, service.ErrNoArtworkImage), errors.Is(err, storage.ErrObjectNotFound):
+		utils.RespondError(w, http.StatusNotFound, "artwork image not found")
 	default:
 		log.Printf("artwork service error: %v", err)
 		utils.RespondServerError(w)
@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 
@@ -31,22 +34,41 @@ type ImageHandler struct {
 	env     *config.Config
 }
 
-func NewImageHandler(db *store.Store, provider storage.Provider, env *config.Config) *ImageHandler {
-	service := service.NewImageService(repo.New(db), provider)
-	return &ImageHandler{service: service, env: env}
+func NewImageHandler(db *store.Store, provider storage.Provider, env *config.Config) (*ImageHandler, error) {
+	service, err := service.NewImageService(repo.New(db), provider, env)
+	if err != nil {
+		return nil, err
+	}
+	return &ImageHandler{service: service, env: env}, nil
 }
 
 func (h *ImageHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 	r.Post("/", h.create)
+	r.Put("/order", h.reorder)
+	r.Post("/uploads", h.createUpload)
+	r.Post("/uploads/finalize", h.finalizeUpload)
+	r.Get("/{id}/similar", h.similar)
 	r.Put("/{id}", h.update)
+	r.Put("/{id}/file", h.replaceFile)
 	r.Delete("/{id}", h.delete)
 	return r
 }
 
+// Batches are processed synchronously within the request, so both the file
+// count and the total body are kept small. Larger sets go through the
+// archive import or direct uploads.
+const (
+	maxBatchFiles      = 10
+	maxBatchUploadSize = 50 * utils.MB
+)
+
 const (
-	attrImg    = "image"
-	attrIsMain = "is_main_image"
+	attrImg     = "image"
+	attrIsMain  = "is_main_image"
+	attrAltText = "alt_text"
+	attrCaption = "caption"
+	attrCredit  = "credit"
 )
 
 func (h *ImageHandler) create(w http.ResponseWriter, r *http.Request) {
@@ -54,8 +76,8 @@ func (h *ImageHandler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	r.Body = http.MaxBytesReader(w, r.Body, 12*utils.MB)
-	data, err := h.parseCreateRequest(r)
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchUploadSize)
+	payload, files, err := h.parseCreateRequest(r)
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrInvalidUUID):
@@ -67,58 +89,148 @@ func (h *ImageHandler) create(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	defer data.File.Close()
 
-	image, err := h.service.Create(r.Context(), data)
+	if len(files) > 1 {
+		report := h.service.CreateBatch(r.Context(), *payload, files)
+		if err := h.service.ResolveReportURLs(r.Context(), report); err != nil {
+			handleImgServiceError(w, err)
+			return
+		}
+		utils.RespondJSON(w, http.StatusOK, report)
+		return
+	}
+
+	image, err := h.service.CreateFromFile(r.Context(), *payload, &files[0])
 	if err != nil {
 		handleImgServiceError(w, err)
 		return
 	}
+	if err := h.service.ResolveImageURL(r.Context(), image, true); err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
 
 	utils.RespondJSON(w, http.StatusOK, image)
 }
 
-func (h *ImageHandler) parseCreateRequest(r *http.Request) (*service.CreateImageData, error) {
-	r.ParseMultipartForm(10 * utils.MB)
+func (h *ImageHandler) parseCreateRequest(r *http.Request) (*domain.CreateImagePayload, []service.UploadFile, error) {
+	if err := r.ParseMultipartForm(10 * utils.MB); err != nil {
+		return nil, nil, ErrInvalidFormData
+	}
 
 	artworkID, err := uuid.Parse(chi.URLParam(r, ArtworkIDParam))
 	if err != nil {
-		return nil, ErrInvalidUUID
+		return nil, nil, ErrInvalidUUID
 	}
 
 	isMainVal := r.FormValue(attrIsMain)
 	isMainImage, err := strconv.ParseBool(isMainVal)
 	if err != nil {
-		return nil, ErrInvalidFormData
+		return nil, nil, ErrInvalidFormData
+	}
+
+	headers := r.MultipartForm.File[attrImg]
+	if len(headers) == 0 || len(headers) > maxBatchFiles {
+		return nil, nil, ErrInvalidFormData
+	}
+
+	files := make([]service.UploadFile, len(headers))
+	for i, header := range headers {
+		files[i] = service.UploadFile{
+			FileName:    header.Filename,
+			ContentType: header.Header.Get("Content-Type"),
+			Load:        func() ([]byte, error) { return readFormFile(header) },
+		}
+	}
+
+	payload := &domain.CreateImagePayload{
+		ArtworkID:   artworkID,
+		IsMainImage: isMainImage,
+		AltText:     utils.NilIfEmpty(r.FormValue(attrAltText)),
+		Caption:     utils.NilIfEmpty(r.FormValue(attrCaption)),
+		Credit:      utils.NilIfEmpty(r.FormValue(attrCredit)),
+	}
+
+	return payload, files, nil
+}
+
+func readFormFile(header *multipart.FileHeader) ([]byte, error) {
+	if header.Size > service.MaxUploadFileSize {
+		return nil, service.ErrFileTooLarge
 	}
 
-	file, fileHeader, err := r.FormFile(attrImg)
+	file, err := header.Open()
 	if err != nil {
 		return nil, ErrInvalidFormData
 	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+func (h *ImageHandler) createUpload(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
 
-	width, height, err := h.service.GetImageDimensions(file)
+	artID, err := uuid.Parse(chi.URLParam(r, ArtworkIDParam))
 	if err != nil {
-		return nil, err
+		utils.RespondError(w, http.StatusBadRequest, "invalid artwork id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1*utils.MB)
+	var body domain.DirectUploadPayload
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
 	}
 
-	return &service.CreateImageData{
-		UploadFileData: storage.UploadFileData{
-			File:        file,
-			FileName:    fileHeader.Filename,
-			ContentType: fileHeader.Header.Get("Content-Type"),
-		},
-		CreateImagePayload: domain.CreateImagePayload{
-			ArtworkID:   artworkID,
-			IsMainImage: isMainImage,
-			ImageWidth:  width,
-			ImageHeight: height,
-		},
-	}, nil
+	upload, err := h.service.CreateUploadURL(r.Context(), artID, &body)
+	if err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, upload)
+}
+
+func (h *ImageHandler) finalizeUpload(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	artID, err := uuid.Parse(chi.URLParam(r, ArtworkIDParam))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid artwork id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1*utils.MB)
+	var body domain.FinalizeUploadPayload
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	image, err := h.service.FinalizeUpload(r.Context(), artID, &body)
+	if err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+	if err := h.service.ResolveImageURL(r.Context(), image, true); err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, image)
 }
 
 type updatePayload struct {
-	IsMainImage string `json:"is_main_image"`
+	IsMainImage string  `json:"is_main_image"`
+	AltText     *string `json:"alt_text"`
+	Caption     *string `json:"caption"`
+	Credit      *string `json:"credit"`
 }
 
 func (h *ImageHandler) update(w http.ResponseWriter, r *http.Request) {
@@ -138,19 +250,151 @@ func (h *ImageHandler) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isMainImage, err := strconv.ParseBool(body.IsMainImage)
+	payload := domain.UpdateImagePayload{
+		AltText: body.AltText,
+		Caption: body.Caption,
+		Credit:  body.Credit,
+	}
+
+	if body.IsMainImage != "" {
+		isMainImage, err := strconv.ParseBool(body.IsMainImage)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		payload.IsMainImage = &isMainImage
+	}
+
+	img, err := h.service.Update(r.Context(), artID, id, &payload)
 	if err != nil {
-		utils.RespondError(w, http.StatusBadRequest, "invalid request body")
+		handleImgServiceError(w, err)
+		return
+	}
+	if err := h.service.ResolveImageURL(r.Context(), img, true); err != nil {
+		handleImgServiceError(w, err)
 		return
 	}
 
-	img, err := h.service.Update(r.Context(), artID, id, isMainImage)
+	utils.RespondJSON(w, http.StatusOK, img)
+}
+
+func (h *ImageHandler) replaceFile(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	id, idErr := uuid.Parse(chi.URLParam(r, "id"))
+	artID, artIDErr := uuid.Parse(chi.URLParam(r, ArtworkIDParam))
+	if idErr != nil || artIDErr != nil {
+		utils.RespondError(w, http.StatusBadRequest, "bad uuid")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxUploadFileSize+1*utils.MB)
+	if err := r.ParseMultipartForm(10 * utils.MB); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid form data")
+		return
+	}
+
+	headers := r.MultipartForm.File[attrImg]
+	if len(headers) != 1 {
+		utils.RespondError(w, http.StatusBadRequest, "invalid form data")
+		return
+	}
+
+	content, err := readFormFile(headers[0])
+	if err != nil {
+		if errors.Is(err, ErrInvalidFormData) {
+			utils.RespondError(w, http.StatusBadRequest, "invalid form data")
+			return
+		}
+		handleImgServiceError(w, err)
+		return
+	}
+
+	file := &service.UploadFile{
+		FileName:    headers[0].Filename,
+		ContentType: headers[0].Header.Get("Content-Type"),
+		Content:     content,
+	}
+
+	image, err := h.service.ReplaceFile(r.Context(), artID, id, file)
 	if err != nil {
 		handleImgServiceError(w, err)
 		return
 	}
+	if err := h.service.ResolveImageURL(r.Context(), image, true); err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
 
-	utils.RespondJSON(w, http.StatusOK, img)
+	utils.RespondJSON(w, http.StatusOK, image)
+}
+
+func (h *ImageHandler) reorder(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	artID, err := uuid.Parse(chi.URLParam(r, ArtworkIDParam))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid artwork id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1*utils.MB)
+	var body domain.ImageOrderPayload
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	images, err := h.service.Reorder(r.Context(), artID, &body)
+	if err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+	if err := h.service.ResolveImageURLs(r.Context(), images, true); err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, images)
+}
+
+func (h *ImageHandler) similar(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	id, idErr := uuid.Parse(chi.URLParam(r, "id"))
+	artID, artIDErr := uuid.Parse(chi.URLParam(r, ArtworkIDParam))
+	if idErr != nil || artIDErr != nil {
+		utils.RespondError(w, http.StatusBadRequest, "bad uuid")
+		return
+	}
+
+	maxDistance := service.DuplicateHashDistance
+	if value := r.URL.Query().Get("max_distance"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid max_distance")
+			return
+		}
+		maxDistance = parsed
+	}
+
+	images, err := h.service.FindSimilar(r.Context(), artID, id, maxDistance)
+	if err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+	if err := h.service.ResolveSimilarURLs(r.Context(), images); err != nil {
+		handleImgServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, images)
 }
 
 func (h *ImageHandler) delete(w http.ResponseWriter, r *http.Request) {
@@ -176,10 +420,30 @@ func (h *ImageHandler) delete(w http.ResponseWriter, r *http.Request) {
 func handleImgServiceError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, service.ErrUnsupportedFormat):
-		utils.RespondError(w, http.StatusBadRequest, "unsupported image format")
+		utils.RespondError(w, http.StatusUnsupportedMediaType, "unsupported image format, expected JPEG, PNG, GIF or WebP")
+	case errors.Is(err, service.ErrContentMismatch):
+		utils.RespondError(w, http.StatusUnsupportedMediaType, "file content does not match its declared type")
+	case errors.Is(err, service.ErrPolyglotImage):
+		utils.RespondError(w, http.StatusUnsupportedMediaType, "file contains non-image data")
+	case errors.Is(err, service.ErrMalformedJPEG), errors.Is(err, service.ErrMalformedWebP):
+		utils.RespondError(w, http.StatusUnsupportedMediaType, "image file is truncated or corrupt")
+	case errors.Is(err, service.ErrFileTooLarge):
+		utils.RespondError(w, http.StatusRequestEntityTooLarge, "file too large")
+	case errors.Is(err, ErrInvalidFormData):
+		utils.RespondError(w, http.StatusBadRequest, "invalid form data")
+	case errors.Is(err, service.ErrInvalidUpload):
+		utils.RespondError(w, http.StatusBadRequest, "invalid upload")
+	case errors.Is(err, service.ErrUploadNotFound):
+		utils.RespondError(w, http.StatusNotFound, "uploaded file not found")
 	case errors.Is(err, sql.ErrNoRows):
-	case errors.Is(err, service.ErrInvalidArtID):
+	case errors.Is(err, service.ErrInvalidArtID), errors.Is(err, service.ErrArtworkNotFound):
 		utils.RespondError(w, http.StatusNotFound, "artwork or image not found")
+	case errors.Is(err, service.ErrInvalidImageOrder):
+		utils.RespondError(w, http.StatusBadRequest, "invalid image order")
+	case errors.Is(err, service.ErrInvalidDistance):
+		utils.RespondError(w, http.StatusBadRequest, fmt.Sprintf("max_distance must be between 0 and %d", service.MaxSimilarHashDistance))
+	case errors.Is(err, storage.ErrObjectNotFound):
+		utils.RespondError(w, http.StatusNotFound, "image file not found")
 	default:
 		log.Printf("image service error: %v", err)
 		utils.RespondServerError(w)
@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/artwork/service"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type PreviewHandler struct {
+	service *service.PreviewService
+	env     *config.Config
+}
+
+func NewPreviewHandler(db *store.Store, env *config.Config) *PreviewHandler {
+	service := service.NewPreviewService(repo.New(db), env)
+	return &PreviewHandler{service: service, env: env}
+}
+
+func (h *PreviewHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", h.list)
+	r.Post("/", h.create)
+	r.Delete("/{id}", h.revoke)
+
+	limiter := utils.NewIPRateLimiter(60, time.Minute)
+	r.With(limiter.Middleware).Get("/public/{token}", h.view)
+
+	return r
+}
+
+func (h *PreviewHandler) list(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	links, err := h.service.List(r.Context())
+	if err != nil {
+		handlePreviewServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, links)
+}
+
+func (h *PreviewHandler) create(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1*utils.MB)
+	var body domain.PreviewPayload
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	link, err := h.service.Create(r.Context(), &body)
+	if err != nil {
+		handlePreviewServiceError(w, err)
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusOK, link)
+}
+
+func (h *PreviewHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	if _, err := utils.Authenticate(w, r, h.env.JwtSecret); err != nil {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid preview id")
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), id); err != nil {
+		handlePreviewServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *PreviewHandler) view(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.service.View(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		handlePreviewServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	utils.RespondJSON(w, http.StatusOK, preview)
+}
+
+func handlePreviewServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrPreviewNotFound):
+		utils.RespondError(w, http.StatusNotFound, "Preview not found")
+	case errors.Is(err, service.ErrArtworkNotFound):
+		utils.RespondError(w, http.StatusNotFound, "Artwork not found")
+	case errors.Is(err, service.ErrInvalidPreview):
+		utils.RespondError(w, http.StatusBadRequest, "invalid preview request")
+	default:
+		log.Printf("preview service error: %v", err)
+		utils.RespondServerError(w)
+	}
+}
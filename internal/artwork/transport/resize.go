@@ -0,0 +1,82 @@
+package transport
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/artwork/service"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const resizeCacheControl = "public, max-age=31536000"
+
+type ResizeHandler struct {
+	service *service.ImageService
+}
+
+func NewResizeHandler(db *store.Store, provider storage.Provider, env *config.Config) (*ResizeHandler, error) {
+	service, err := service.NewImageService(repo.New(db), provider, env)
+	if err != nil {
+		return nil, err
+	}
+	return &ResizeHandler{service: service}, nil
+}
+
+func (h *ResizeHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	limiter := utils.NewIPRateLimiter(300, time.Minute)
+	r.With(limiter.Middleware).Get("/{imageID}", h.resize)
+
+	return r
+}
+
+func (h *ResizeHandler) resize(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "imageID"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid image id")
+		return
+	}
+
+	width, err := strconv.Atoi(r.URL.Query().Get("w"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid width")
+		return
+	}
+
+	format := r.URL.Query().Get("fmt")
+	if format == "" || format == "jpg" {
+		format = "jpeg"
+	}
+
+	resized, err := h.service.Resize(r.Context(), id, width, format)
+	if err != nil {
+		handleResizeError(w, err)
+		return
+	}
+
+	respondRenderedImage(w, r, resized, resizeCacheControl)
+}
+
+func handleResizeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWidth):
+		utils.RespondError(w, http.StatusBadRequest, "width not allowed")
+	case errors.Is(err, service.ErrInvalidFormat):
+		utils.RespondError(w, http.StatusBadRequest, "format not allowed")
+	case errors.Is(err, service.ErrImageNotFound):
+		utils.RespondError(w, http.StatusNotFound, "image not found")
+	default:
+		log.Printf("image resize error: %v", err)
+		utils.RespondServerError(w)
+	}
+}
@@ -3,14 +3,31 @@ package transport
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 
 	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/service"
+	"github.com/art-vbst/art-backend/internal/platform/utils"
 )
 
 var (
-	ErrInvalidArtworkStatus = errors.New("provided artwork status is invalid")
+	ErrInvalidArtworkStatus     = errors.New("provided artwork status is invalid")
+	ErrInvalidArtworkCategory   = errors.New("provided artwork category is invalid")
+	ErrInvalidMeasurementSystem = errors.New("provided measurement system is invalid")
+	ErrInvalidYear              = errors.New("provided year is invalid")
 )
 
+func isAdmin(r *http.Request, secret string) bool {
+	cookie, err := r.Cookie(utils.AccessCookieName)
+	if err != nil {
+		return false
+	}
+
+	_, err = utils.ParseAccessToken(cookie.Value, secret)
+	return err == nil
+}
+
 func parseArtworkStatuses(values []string) ([]domain.ArtworkStatus, error) {
 	valid := map[domain.ArtworkStatus]bool{
 		domain.ArtworkStatusAvailable:   true,
@@ -31,3 +48,62 @@ func parseArtworkStatuses(values []string) ([]domain.ArtworkStatus, error) {
 
 	return out, nil
 }
+
+func parseMeasurementSystem(value string) (domain.MeasurementSystem, error) {
+	switch domain.MeasurementSystem(value) {
+	case "", domain.MeasurementSystemImperial:
+		return domain.MeasurementSystemImperial, nil
+	case domain.MeasurementSystemMetric:
+		return domain.MeasurementSystemMetric, nil
+	default:
+		return "", ErrInvalidMeasurementSystem
+	}
+}
+
+func parseArtworkCategories(values []string) ([]domain.ArtworkCategory, error) {
+	valid := map[domain.ArtworkCategory]bool{
+		domain.ArtworkCategoryFigure:      true,
+		domain.ArtworkCategoryLandscape:   true,
+		domain.ArtworkCategoryMultiFigure: true,
+		domain.ArtworkCategoryOther:       true,
+	}
+
+	out := make([]domain.ArtworkCategory, 0, len(values))
+	for _, v := range values {
+		category := domain.ArtworkCategory(v)
+		if _, ok := valid[category]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidArtworkCategory, v)
+		}
+		out = append(out, category)
+	}
+
+	return out, nil
+}
+
+func parseYear(value string) (*int32, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	year, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidYear
+	}
+
+	y := int32(year)
+	return &y, nil
+}
+
+func respondRenderedImage(w http.ResponseWriter, r *http.Request, img *service.RenderedImage, cacheControl string) {
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("ETag", img.ETag)
+	if r.Header.Get("If-None-Match") == img.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(img.Content)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(img.Content)
+}
@@ -9,6 +9,7 @@ import (
 
 	artdomain "github.com/art-vbst/art-backend/internal/artwork/domain"
 	artrepo "github.com/art-vbst/art-backend/internal/artwork/repo"
+	artservice "github.com/art-vbst/art-backend/internal/artwork/service"
 	paydomain "github.com/art-vbst/art-backend/internal/payments/domain"
 	payrepo "github.com/art-vbst/art-backend/internal/payments/repo"
 	"github.com/art-vbst/art-backend/internal/platform/config"
@@ -199,7 +200,7 @@ func (s *CheckoutService) buildLineItems(artworks []artdomain.Artwork) []*stripe
 	for _, artwork := range artworks {
 		imageURL := ""
 		if len(artwork.Images) > 0 {
-			imageURL = artwork.Images[0].ImageURL
+			imageURL = artservice.PublicImageURL(&artwork.Images[0])
 		}
 
 		productData := stripe.CheckoutSessionLineItemPriceDataProductDataParams{
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"reflect"
 	"slices"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,8 +21,19 @@ type Config struct {
 	JwtSecret           string
 	TOTPSecret          string
 	DbUrl               string
+	StorageProvider     string
 	GCSBucketName       string
+	S3BucketName        string
+	S3Endpoint          string
+	S3Region            string
+	S3AccessKeyID       string
+	S3SecretAccessKey   string
+	S3PathStyle         bool
+	S3PublicURL         string
 	LocalStorageDir     string
+	PrivateOriginals    bool
+	StorageNaming       string
+	SignedURLExpiry     time.Duration
 	StripeSecret        string
 	StripeWebhookSecret string
 	MailgunDomain       string
@@ -26,6 +41,17 @@ type Config struct {
 	TestEmail           string
 	EmailFromName       string
 	EmailSignature      string
+	CatalogNumbering    string
+	ExifKeepCopyright   bool
+	WatermarkText       string
+	WatermarkImage      string
+	WatermarkPosition   string
+	WatermarkOpacity    float64
+	WatermarkScale      float64
+	MockupTemplate      string
+	MockupPixelsPerInch float64
+	MockupCenterX       float64
+	MockupCenterY       float64
 }
 
 func IsDebug() bool {
@@ -43,8 +69,19 @@ func Load() *Config {
 		JwtSecret:           os.Getenv("JWT_SECRET"),
 		TOTPSecret:          os.Getenv("TOTP_SECRET"),
 		DbUrl:               os.Getenv("DB_URL"),
+		StorageProvider:     os.Getenv("STORAGE_PROVIDER"),
 		GCSBucketName:       os.Getenv("GCS_BUCKET_NAME"),
+		S3BucketName:        os.Getenv("S3_BUCKET_NAME"),
+		S3Endpoint:          os.Getenv("S3_ENDPOINT"),
+		S3Region:            os.Getenv("S3_REGION"),
+		S3AccessKeyID:       os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey:   os.Getenv("S3_SECRET_ACCESS_KEY"),
+		S3PathStyle:         os.Getenv("S3_PATH_STYLE") == "true",
+		S3PublicURL:         os.Getenv("S3_PUBLIC_URL"),
 		LocalStorageDir:     os.Getenv("LOCAL_STORAGE_DIR"),
+		PrivateOriginals:    os.Getenv("STORAGE_PRIVATE_ORIGINALS") == "true",
+		StorageNaming:       os.Getenv("STORAGE_NAMING"),
+		SignedURLExpiry:     parseDurationVar("SIGNED_URL_EXPIRY", 15*time.Minute),
 		StripeSecret:        os.Getenv("STRIPE_SECRET"),
 		StripeWebhookSecret: os.Getenv("STRIPE_WEBHOOK_SECRET"),
 		MailgunDomain:       os.Getenv("MAILGUN_DOMAIN"),
@@ -52,17 +89,132 @@ func Load() *Config {
 		TestEmail:           os.Getenv("TEST_EMAIL"),
 		EmailFromName:       os.Getenv("EMAIL_FROM_NAME"),
 		EmailSignature:      os.Getenv("EMAIL_SIGNATURE"),
+		CatalogNumbering:    os.Getenv("CATALOG_NUMBERING"),
+		ExifKeepCopyright:   os.Getenv("EXIF_KEEP_COPYRIGHT") == "true",
+		WatermarkText:       os.Getenv("WATERMARK_TEXT"),
+		WatermarkImage:      os.Getenv("WATERMARK_IMAGE"),
+		WatermarkPosition:   os.Getenv("WATERMARK_POSITION"),
+		WatermarkOpacity:    parseFloatVar("WATERMARK_OPACITY", 0.3),
+		WatermarkScale:      parseFloatVar("WATERMARK_SCALE", 0.2),
+		MockupTemplate:      os.Getenv("MOCKUP_TEMPLATE"),
+		MockupPixelsPerInch: parseFloatVar("MOCKUP_PIXELS_PER_INCH", 0),
+		MockupCenterX:       parseFloatVar("MOCKUP_CENTER_X", 0.5),
+		MockupCenterY:       parseFloatVar("MOCKUP_CENTER_Y", 0.4),
 	}
 
 	if config.Port == "" {
 		config.Port = "8080"
 	}
 
+	if config.CatalogNumbering == "" {
+		config.CatalogNumbering = "global"
+	}
+	if !slices.Contains([]string{"global", "year", "medium"}, config.CatalogNumbering) {
+		log.Fatalf("Invalid CATALOG_NUMBERING value: %s", config.CatalogNumbering)
+	}
+
+	if config.StorageProvider == "" {
+		config.StorageProvider = "gcs"
+	}
+	if config.S3Region == "" {
+		config.S3Region = "us-east-1"
+	}
+	if config.S3Endpoint == "" {
+		config.S3Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.S3Region)
+	}
+	ensureStorageVars(&config)
+	if config.StorageNaming == "" {
+		config.StorageNaming = "timestamp"
+	}
+	if !slices.Contains([]string{"timestamp", "content"}, config.StorageNaming) {
+		log.Fatalf("Invalid STORAGE_NAMING value: %s", config.StorageNaming)
+	}
+	if config.SignedURLExpiry <= 0 || config.SignedURLExpiry > 7*24*time.Hour {
+		log.Fatalf("Invalid SIGNED_URL_EXPIRY value: %s", config.SignedURLExpiry)
+	}
+
+	if config.WatermarkText != "" && config.WatermarkImage != "" {
+		log.Fatal("WATERMARK_TEXT and WATERMARK_IMAGE cannot both be set")
+	}
+	if config.WatermarkPosition == "" {
+		config.WatermarkPosition = "bottom-right"
+	}
+	if !slices.Contains([]string{"top-left", "top-right", "bottom-left", "bottom-right", "center"}, config.WatermarkPosition) {
+		log.Fatalf("Invalid WATERMARK_POSITION value: %s", config.WatermarkPosition)
+	}
+	if config.WatermarkOpacity <= 0 || config.WatermarkOpacity > 1 {
+		log.Fatalf("Invalid WATERMARK_OPACITY value: %v", config.WatermarkOpacity)
+	}
+	if config.WatermarkScale <= 0 || config.WatermarkScale > 1 {
+		log.Fatalf("Invalid WATERMARK_SCALE value: %v", config.WatermarkScale)
+	}
+
+	if config.MockupTemplate != "" && config.MockupPixelsPerInch <= 0 {
+		log.Fatal("MOCKUP_PIXELS_PER_INCH must be set when MOCKUP_TEMPLATE is configured")
+	}
+	if config.MockupCenterX < 0 || config.MockupCenterX > 1 || config.MockupCenterY < 0 || config.MockupCenterY > 1 {
+		log.Fatal("MOCKUP_CENTER_X and MOCKUP_CENTER_Y must be between 0 and 1")
+	}
+
 	ensureRequiredVars(&config)
 
 	return &config
 }
 
+func ensureStorageVars(config *Config) {
+	var required map[string]string
+	switch config.StorageProvider {
+	case "gcs":
+		required = map[string]string{"GCS_BUCKET_NAME": config.GCSBucketName}
+	case "s3":
+		required = map[string]string{
+			"S3_BUCKET_NAME":       config.S3BucketName,
+			"S3_ACCESS_KEY_ID":     config.S3AccessKeyID,
+			"S3_SECRET_ACCESS_KEY": config.S3SecretAccessKey,
+		}
+		if endpoint, err := url.Parse(config.S3Endpoint); err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+			log.Fatalf("Invalid S3_ENDPOINT value: %s", config.S3Endpoint)
+		}
+	default:
+		log.Fatalf("Invalid STORAGE_PROVIDER value: %s", config.StorageProvider)
+	}
+
+	if IsDebug() && config.LocalStorageDir != "" {
+		return
+	}
+	for name, value := range required {
+		if value == "" {
+			log.Fatalf("Missing required environment variable: %s", name)
+		}
+	}
+}
+
+func parseFloatVar(name string, fallback float64) float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", name, value)
+	}
+	return parsed
+}
+
+func parseDurationVar(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", name, value)
+	}
+	return parsed
+}
+
 func loadRoutedEnvFile() {
 	env := os.Getenv("ENV")
 	if env == "" {
@@ -88,7 +240,11 @@ func loadRoutedEnvFile() {
 }
 
 func ensureRequiredVars(config *Config) {
-	optionalVars := []string{"Debug", "TestEmail", "LocalStorageDir"}
+	optionalVars := []string{
+		"Debug", "TestEmail", "LocalStorageDir", "ExifKeepCopyright",
+		"PrivateOriginals", "GCSBucketName", "S3BucketName", "S3AccessKeyID", "S3SecretAccessKey", "S3PathStyle", "S3PublicURL",
+		"WatermarkText", "WatermarkImage", "MockupTemplate",
+	}
 
 	typ := reflect.TypeOf(*config)
 	val := reflect.ValueOf(*config)
@@ -19,6 +19,8 @@ INSERT INTO artworks (
         painting_year,
         width_inches,
         height_inches,
+        depth_inches,
+        weight_pounds,
         price_cents,
         description,
         paper,
@@ -37,9 +39,11 @@ VALUES (
         $8,
         $9,
         $10,
-        $11
+        $11,
+        $12,
+        $13
     )
-RETURNING id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description
+RETURNING id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description, depth_inches, weight_pounds
 `
 
 type CreateArtworkParams struct {
@@ -48,6 +52,8 @@ type CreateArtworkParams struct {
 	PaintingYear   *int32          `db:"painting_year" json:"painting_year"`
 	WidthInches    pgtype.Numeric  `db:"width_inches" json:"width_inches"`
 	HeightInches   pgtype.Numeric  `db:"height_inches" json:"height_inches"`
+	DepthInches    pgtype.Numeric  `db:"depth_inches" json:"depth_inches"`
+	WeightPounds   pgtype.Numeric  `db:"weight_pounds" json:"weight_pounds"`
 	PriceCents     int32           `db:"price_cents" json:"price_cents"`
 	Description    *string         `db:"description" json:"description"`
 	Paper          *bool           `db:"paper" json:"paper"`
@@ -63,6 +69,8 @@ func (q *Queries) CreateArtwork(ctx context.Context, arg CreateArtworkParams) (A
 		arg.PaintingYear,
 		arg.WidthInches,
 		arg.HeightInches,
+		arg.DepthInches,
+		arg.WeightPounds,
 		arg.PriceCents,
 		arg.Description,
 		arg.Paper,
@@ -88,10 +96,45 @@ func (q *Queries) CreateArtwork(ctx context.Context, arg CreateArtworkParams) (A
 		&i.CreatedAt,
 		&i.OrderID,
 		&i.Description,
+		&i.DepthInches,
+		&i.WeightPounds,
 	)
 	return i, err
 }
 
+const createGlobalPaintingNumberUniqueIndex = `-- name: CreateGlobalPaintingNumberUniqueIndex :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artworks_painting_number_global
+ON artworks (painting_number)
+WHERE painting_number IS NOT NULL
+`
+
+func (q *Queries) CreateGlobalPaintingNumberUniqueIndex(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, createGlobalPaintingNumberUniqueIndex)
+	return err
+}
+
+const createMediumPaintingNumberUniqueIndex = `-- name: CreateMediumPaintingNumberUniqueIndex :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artworks_painting_number_medium
+ON artworks (medium, painting_number)
+WHERE painting_number IS NOT NULL
+`
+
+func (q *Queries) CreateMediumPaintingNumberUniqueIndex(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, createMediumPaintingNumberUniqueIndex)
+	return err
+}
+
+const createYearPaintingNumberUniqueIndex = `-- name: CreateYearPaintingNumberUniqueIndex :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artworks_painting_number_year
+ON artworks (coalesce(painting_year, -1), painting_number)
+WHERE painting_number IS NOT NULL
+`
+
+func (q *Queries) CreateYearPaintingNumberUniqueIndex(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, createYearPaintingNumberUniqueIndex)
+	return err
+}
+
 const deleteArtwork = `-- name: DeleteArtwork :exec
 DELETE FROM artworks
 WHERE id = $1
@@ -102,19 +145,39 @@ func (q *Queries) DeleteArtwork(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const dropPaintingNumberUniqueIndexes = `-- name: DropPaintingNumberUniqueIndexes :exec
+DROP INDEX IF EXISTS idx_artworks_painting_number_global,
+idx_artworks_painting_number_year,
+idx_artworks_painting_number_medium
+`
+
+func (q *Queries) DropPaintingNumberUniqueIndexes(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, dropPaintingNumberUniqueIndexes)
+	return err
+}
+
 const getArtworkWithImages = `-- name: GetArtworkWithImages :many
-SELECT a.id, a.title, a.painting_number, a.painting_year, a.width_inches, a.height_inches, a.price_cents, a.paper, a.sort_order, a.sold_at, a.status, a.medium, a.category, a.created_at, a.order_id, a.description,
+SELECT a.id, a.title, a.painting_number, a.painting_year, a.width_inches, a.height_inches, a.price_cents, a.paper, a.sort_order, a.sold_at, a.status, a.medium, a.category, a.created_at, a.order_id, a.description, a.depth_inches, a.weight_pounds,
     i.id as image_id,
     i.is_main_image,
     i.object_name,
     i.image_url,
     i.image_width,
     i.image_height,
+    i.variants,
+    i.blurhash,
+    i.dominant_color,
+    i.palette,
+    i.alt_text,
+    i.caption,
+    i.credit,
+    i.position,
     i.created_at as image_created_at
 FROM artworks a
     LEFT JOIN images i ON a.id = i.artwork_id
 WHERE a.id = $1
-ORDER BY i.created_at
+ORDER BY i.position,
+    i.created_at
 `
 
 type GetArtworkWithImagesRow struct {
@@ -134,12 +197,22 @@ type GetArtworkWithImagesRow struct {
 	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
 	OrderID        pgtype.UUID      `db:"order_id" json:"order_id"`
 	Description    *string          `db:"description" json:"description"`
+	DepthInches    pgtype.Numeric   `db:"depth_inches" json:"depth_inches"`
+	WeightPounds   pgtype.Numeric   `db:"weight_pounds" json:"weight_pounds"`
 	ImageID        pgtype.UUID      `db:"image_id" json:"image_id"`
 	IsMainImage    *bool            `db:"is_main_image" json:"is_main_image"`
 	ObjectName     *string          `db:"object_name" json:"object_name"`
 	ImageUrl       *string          `db:"image_url" json:"image_url"`
 	ImageWidth     *int32           `db:"image_width" json:"image_width"`
 	ImageHeight    *int32           `db:"image_height" json:"image_height"`
+	Variants       []byte           `db:"variants" json:"variants"`
+	Blurhash       *string          `db:"blurhash" json:"blurhash"`
+	DominantColor  *string          `db:"dominant_color" json:"dominant_color"`
+	Palette        []byte           `db:"palette" json:"palette"`
+	AltText        *string          `db:"alt_text" json:"alt_text"`
+	Caption        *string          `db:"caption" json:"caption"`
+	Credit         *string          `db:"credit" json:"credit"`
+	Position       *int32           `db:"position" json:"position"`
 	ImageCreatedAt pgtype.Timestamp `db:"image_created_at" json:"image_created_at"`
 }
 
@@ -169,12 +242,22 @@ func (q *Queries) GetArtworkWithImages(ctx context.Context, id uuid.UUID) ([]Get
 			&i.CreatedAt,
 			&i.OrderID,
 			&i.Description,
+			&i.DepthInches,
+			&i.WeightPounds,
 			&i.ImageID,
 			&i.IsMainImage,
 			&i.ObjectName,
 			&i.ImageUrl,
 			&i.ImageWidth,
 			&i.ImageHeight,
+			&i.Variants,
+			&i.Blurhash,
+			&i.DominantColor,
+			&i.Palette,
+			&i.AltText,
+			&i.Caption,
+			&i.Credit,
+			&i.Position,
 			&i.ImageCreatedAt,
 		); err != nil {
 			return nil, err
@@ -187,19 +270,60 @@ func (q *Queries) GetArtworkWithImages(ctx context.Context, id uuid.UUID) ([]Get
 	return items, nil
 }
 
+const listArtworkSortOrdersForUpdate = `-- name: ListArtworkSortOrdersForUpdate :many
+SELECT id,
+    sort_order
+FROM artworks
+ORDER BY sort_order,
+    created_at DESC FOR
+UPDATE
+`
+
+type ListArtworkSortOrdersForUpdateRow struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	SortOrder int32     `db:"sort_order" json:"sort_order"`
+}
+
+func (q *Queries) ListArtworkSortOrdersForUpdate(ctx context.Context) ([]ListArtworkSortOrdersForUpdateRow, error) {
+	rows, err := q.db.Query(ctx, listArtworkSortOrdersForUpdate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArtworkSortOrdersForUpdateRow
+	for rows.Next() {
+		var i ListArtworkSortOrdersForUpdateRow
+		if err := rows.Scan(&i.ID, &i.SortOrder); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listArtworkStripeData = `-- name: ListArtworkStripeData :many
 SELECT a.id,
     a.title,
+    a.width_inches,
+    a.height_inches,
+    a.depth_inches,
+    a.weight_pounds,
     a.price_cents,
     a.status,
-    i.image_id, i.image_url
+    i.image_id, i.image_url, i.object_name, i.variants
 FROM artworks a
     LEFT JOIN LATERAL (
         SELECT id as image_id,
-            image_url
+            image_url,
+            object_name,
+            variants
         FROM images
         WHERE artwork_id = a.id
         ORDER BY is_main_image DESC NULLS LAST,
+            position,
             created_at
         LIMIT 1
     ) i ON true
@@ -208,12 +332,18 @@ WHERE a.id = ANY($1::uuid [])
 `
 
 type ListArtworkStripeDataRow struct {
-	ID         uuid.UUID     `db:"id" json:"id"`
-	Title      string        `db:"title" json:"title"`
-	PriceCents int32         `db:"price_cents" json:"price_cents"`
-	Status     ArtworkStatus `db:"status" json:"status"`
-	ImageID    uuid.UUID     `db:"image_id" json:"image_id"`
-	ImageUrl   string        `db:"image_url" json:"image_url"`
+	ID           uuid.UUID      `db:"id" json:"id"`
+	Title        string         `db:"title" json:"title"`
+	WidthInches  pgtype.Numeric `db:"width_inches" json:"width_inches"`
+	HeightInches pgtype.Numeric `db:"height_inches" json:"height_inches"`
+	DepthInches  pgtype.Numeric `db:"depth_inches" json:"depth_inches"`
+	WeightPounds pgtype.Numeric `db:"weight_pounds" json:"weight_pounds"`
+	PriceCents   int32          `db:"price_cents" json:"price_cents"`
+	Status       ArtworkStatus  `db:"status" json:"status"`
+	ImageID      uuid.UUID      `db:"image_id" json:"image_id"`
+	ImageUrl     string         `db:"image_url" json:"image_url"`
+	ObjectName   string         `db:"object_name" json:"object_name"`
+	Variants     []byte         `db:"variants" json:"variants"`
 }
 
 func (q *Queries) ListArtworkStripeData(ctx context.Context, dollar_1 []uuid.UUID) ([]ListArtworkStripeDataRow, error) {
@@ -228,10 +358,16 @@ func (q *Queries) ListArtworkStripeData(ctx context.Context, dollar_1 []uuid.UUI
 		if err := rows.Scan(
 			&i.ID,
 			&i.Title,
+			&i.WidthInches,
+			&i.HeightInches,
+			&i.DepthInches,
+			&i.WeightPounds,
 			&i.PriceCents,
 			&i.Status,
 			&i.ImageID,
 			&i.ImageUrl,
+			&i.ObjectName,
+			&i.Variants,
 		); err != nil {
 			return nil, err
 		}
@@ -244,12 +380,19 @@ func (q *Queries) ListArtworkStripeData(ctx context.Context, dollar_1 []uuid.UUI
 }
 
 const listArtworks = `-- name: ListArtworks :many
-SELECT a.id, a.title, a.painting_number, a.painting_year, a.width_inches, a.height_inches, a.price_cents, a.paper, a.sort_order, a.sold_at, a.status, a.medium, a.category, a.created_at, a.order_id, a.description,
+SELECT a.id, a.title, a.painting_number, a.painting_year, a.width_inches, a.height_inches, a.price_cents, a.paper, a.sort_order, a.sold_at, a.status, a.medium, a.category, a.created_at, a.order_id, a.description, a.depth_inches, a.weight_pounds,
     i.image_id,
     COALESCE(i.object_name, '') as object_name,
     COALESCE(i.image_url, '') as image_url,
     i.image_width,
     i.image_height,
+    i.variants,
+    i.blurhash,
+    i.dominant_color,
+    i.palette,
+    i.alt_text,
+    i.caption,
+    i.credit,
     i.image_created_at
 FROM artworks a
     LEFT JOIN LATERAL (
@@ -258,10 +401,18 @@ FROM artworks a
             image_url,
             image_width,
             image_height,
+            variants,
+            blurhash,
+            dominant_color,
+            palette,
+            alt_text,
+            caption,
+            credit,
             created_at as image_created_at
         FROM images
         WHERE artwork_id = a.id
         ORDER BY is_main_image DESC NULLS LAST,
+            position,
             created_at
         LIMIT 1
     ) i ON true
@@ -289,11 +440,20 @@ type ListArtworksRow struct {
 	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
 	OrderID        pgtype.UUID      `db:"order_id" json:"order_id"`
 	Description    *string          `db:"description" json:"description"`
+	DepthInches    pgtype.Numeric   `db:"depth_inches" json:"depth_inches"`
+	WeightPounds   pgtype.Numeric   `db:"weight_pounds" json:"weight_pounds"`
 	ImageID        uuid.UUID        `db:"image_id" json:"image_id"`
 	ObjectName     string           `db:"object_name" json:"object_name"`
 	ImageUrl       string           `db:"image_url" json:"image_url"`
 	ImageWidth     *int32           `db:"image_width" json:"image_width"`
 	ImageHeight    *int32           `db:"image_height" json:"image_height"`
+	Variants       []byte           `db:"variants" json:"variants"`
+	Blurhash       *string          `db:"blurhash" json:"blurhash"`
+	DominantColor  *string          `db:"dominant_color" json:"dominant_color"`
+	Palette        []byte           `db:"palette" json:"palette"`
+	AltText        *string          `db:"alt_text" json:"alt_text"`
+	Caption        *string          `db:"caption" json:"caption"`
+	Credit         *string          `db:"credit" json:"credit"`
 	ImageCreatedAt pgtype.Timestamp `db:"image_created_at" json:"image_created_at"`
 }
 
@@ -323,11 +483,20 @@ func (q *Queries) ListArtworks(ctx context.Context, dollar_1 []string) ([]ListAr
 			&i.CreatedAt,
 			&i.OrderID,
 			&i.Description,
+			&i.DepthInches,
+			&i.WeightPounds,
 			&i.ImageID,
 			&i.ObjectName,
 			&i.ImageUrl,
 			&i.ImageWidth,
 			&i.ImageHeight,
+			&i.Variants,
+			&i.Blurhash,
+			&i.DominantColor,
+			&i.Palette,
+			&i.AltText,
+			&i.Caption,
+			&i.Credit,
 			&i.ImageCreatedAt,
 		); err != nil {
 			return nil, err
@@ -340,8 +509,90 @@ func (q *Queries) ListArtworks(ctx context.Context, dollar_1 []string) ([]ListAr
 	return items, nil
 }
 
+const listCatalogNumbers = `-- name: ListCatalogNumbers :many
+SELECT id,
+    title,
+    painting_number,
+    painting_year,
+    medium
+FROM artworks
+ORDER BY painting_number NULLS LAST,
+    created_at
+`
+
+type ListCatalogNumbersRow struct {
+	ID             uuid.UUID     `db:"id" json:"id"`
+	Title          string        `db:"title" json:"title"`
+	PaintingNumber *int32        `db:"painting_number" json:"painting_number"`
+	PaintingYear   *int32        `db:"painting_year" json:"painting_year"`
+	Medium         ArtworkMedium `db:"medium" json:"medium"`
+}
+
+func (q *Queries) ListCatalogNumbers(ctx context.Context) ([]ListCatalogNumbersRow, error) {
+	rows, err := q.db.Query(ctx, listCatalogNumbers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCatalogNumbersRow
+	for rows.Next() {
+		var i ListCatalogNumbersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.PaintingNumber,
+			&i.PaintingYear,
+			&i.Medium,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPaintingNumberIndexes = `-- name: ListPaintingNumberIndexes :many
+SELECT indexname::text
+FROM pg_indexes
+WHERE tablename = 'artworks'
+    AND indexname LIKE 'idx_artworks_painting_number_%'
+ORDER BY indexname
+`
+
+func (q *Queries) ListPaintingNumberIndexes(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listPaintingNumberIndexes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var indexname string
+		if err := rows.Scan(&indexname); err != nil {
+			return nil, err
+		}
+		items = append(items, indexname)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCatalogNumbers = `-- name: LockCatalogNumbers :exec
+SELECT pg_advisory_xact_lock(hashtext('artworks.painting_number'))
+`
+
+func (q *Queries) LockCatalogNumbers(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockCatalogNumbers)
+	return err
+}
+
 const selectArtworksForUpdate = `-- name: SelectArtworksForUpdate :many
-SELECT id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description
+SELECT id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description, depth_inches, weight_pounds
 FROM artworks
 WHERE id = ANY($1::uuid [])
     AND status = 'available' FOR
@@ -374,6 +625,8 @@ func (q *Queries) SelectArtworksForUpdate(ctx context.Context, dollar_1 []uuid.U
 			&i.CreatedAt,
 			&i.OrderID,
 			&i.Description,
+			&i.DepthInches,
+			&i.WeightPounds,
 		); err != nil {
 			return nil, err
 		}
@@ -392,15 +645,17 @@ SET title = $2,
     painting_year = $4,
     width_inches = $5,
     height_inches = $6,
-    price_cents = $7,
-    description = $8,
-    paper = $9,
-    sort_order = $10,
-    status = $11,
-    medium = $12,
-    category = $13
+    depth_inches = $7,
+    weight_pounds = $8,
+    price_cents = $9,
+    description = $10,
+    paper = $11,
+    sort_order = $12,
+    status = $13,
+    medium = $14,
+    category = $15
 WHERE id = $1
-RETURNING id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description
+RETURNING id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description, depth_inches, weight_pounds
 `
 
 type UpdateArtworkParams struct {
@@ -410,6 +665,8 @@ type UpdateArtworkParams struct {
 	PaintingYear   *int32          `db:"painting_year" json:"painting_year"`
 	WidthInches    pgtype.Numeric  `db:"width_inches" json:"width_inches"`
 	HeightInches   pgtype.Numeric  `db:"height_inches" json:"height_inches"`
+	DepthInches    pgtype.Numeric  `db:"depth_inches" json:"depth_inches"`
+	WeightPounds   pgtype.Numeric  `db:"weight_pounds" json:"weight_pounds"`
 	PriceCents     int32           `db:"price_cents" json:"price_cents"`
 	Description    *string         `db:"description" json:"description"`
 	Paper          *bool           `db:"paper" json:"paper"`
@@ -427,6 +684,8 @@ func (q *Queries) UpdateArtwork(ctx context.Context, arg UpdateArtworkParams) (A
 		arg.PaintingYear,
 		arg.WidthInches,
 		arg.HeightInches,
+		arg.DepthInches,
+		arg.WeightPounds,
 		arg.PriceCents,
 		arg.Description,
 		arg.Paper,
@@ -453,17 +712,36 @@ func (q *Queries) UpdateArtwork(ctx context.Context, arg UpdateArtworkParams) (A
 		&i.CreatedAt,
 		&i.OrderID,
 		&i.Description,
+		&i.DepthInches,
+		&i.WeightPounds,
 	)
 	return i, err
 }
 
+const updateArtworkSortOrders = `-- name: UpdateArtworkSortOrders :exec
+UPDATE artworks
+SET sort_order = ($2::integer [])[v.position]
+FROM unnest($1::uuid []) WITH ORDINALITY AS v (id, position)
+WHERE artworks.id = v.id
+`
+
+type UpdateArtworkSortOrdersParams struct {
+	Column1 []uuid.UUID `db:"column_1" json:"column_1"`
+	Column2 []int32     `db:"column_2" json:"column_2"`
+}
+
+func (q *Queries) UpdateArtworkSortOrders(ctx context.Context, arg UpdateArtworkSortOrdersParams) error {
+	_, err := q.db.Exec(ctx, updateArtworkSortOrders, arg.Column1, arg.Column2)
+	return err
+}
+
 const updateArtworksAsPurchased = `-- name: UpdateArtworksAsPurchased :many
 UPDATE artworks
 SET status = 'sold',
     sold_at = current_timestamp,
     order_id = $2
 WHERE id = ANY($1::uuid [])
-RETURNING id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description
+RETURNING id, title, painting_number, painting_year, width_inches, height_inches, price_cents, paper, sort_order, sold_at, status, medium, category, created_at, order_id, description, depth_inches, weight_pounds
 `
 
 type UpdateArtworksAsPurchasedParams struct {
@@ -497,6 +775,8 @@ func (q *Queries) UpdateArtworksAsPurchased(ctx context.Context, arg UpdateArtwo
 			&i.CreatedAt,
 			&i.OrderID,
 			&i.Description,
+			&i.DepthInches,
+			&i.WeightPounds,
 		); err != nil {
 			return nil, err
 		}
@@ -12,6 +12,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const clearMainImage = `-- name: ClearMainImage :exec
+UPDATE images
+SET is_main_image = FALSE
+WHERE artwork_id = $1
+    AND is_main_image
+`
+
+func (q *Queries) ClearMainImage(ctx context.Context, artworkID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, clearMainImage, artworkID)
+	return err
+}
+
 const createImage = `-- name: CreateImage :one
 INSERT INTO images (
         artwork_id,
@@ -19,19 +31,56 @@ INSERT INTO images (
         image_url,
         is_main_image,
         image_width,
-        image_height
+        image_height,
+        variants,
+        blurhash,
+        dominant_color,
+        alt_text,
+        caption,
+        credit,
+        phash,
+        palette,
+        position
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13,
+        $14,
+        (
+            SELECT COALESCE(MAX(position), 0) + 1
+            FROM images
+            WHERE artwork_id = $1
+        )
     )
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at
+RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
 `
 
 type CreateImageParams struct {
-	ArtworkID   pgtype.UUID `db:"artwork_id" json:"artwork_id"`
-	ObjectName  string      `db:"object_name" json:"object_name"`
-	ImageUrl    string      `db:"image_url" json:"image_url"`
-	IsMainImage bool        `db:"is_main_image" json:"is_main_image"`
-	ImageWidth  *int32      `db:"image_width" json:"image_width"`
-	ImageHeight *int32      `db:"image_height" json:"image_height"`
+	ArtworkID     pgtype.UUID `db:"artwork_id" json:"artwork_id"`
+	ObjectName    string      `db:"object_name" json:"object_name"`
+	ImageUrl      string      `db:"image_url" json:"image_url"`
+	IsMainImage   bool        `db:"is_main_image" json:"is_main_image"`
+	ImageWidth    *int32      `db:"image_width" json:"image_width"`
+	ImageHeight   *int32      `db:"image_height" json:"image_height"`
+	Variants      []byte      `db:"variants" json:"variants"`
+	Blurhash      *string     `db:"blurhash" json:"blurhash"`
+	DominantColor *string     `db:"dominant_color" json:"dominant_color"`
+	AltText       *string     `db:"alt_text" json:"alt_text"`
+	Caption       *string     `db:"caption" json:"caption"`
+	Credit        *string     `db:"credit" json:"credit"`
+	Phash         *int64      `db:"phash" json:"phash"`
+	Palette       []byte      `db:"palette" json:"palette"`
 }
 
 func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (Image, error) {
@@ -42,6 +91,14 @@ func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (Image
 		arg.IsMainImage,
 		arg.ImageWidth,
 		arg.ImageHeight,
+		arg.Variants,
+		arg.Blurhash,
+		arg.DominantColor,
+		arg.AltText,
+		arg.Caption,
+		arg.Credit,
+		arg.Phash,
+		arg.Palette,
 	)
 	var i Image
 	err := row.Scan(
@@ -53,22 +110,52 @@ func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (Image
 		&i.ImageWidth,
 		&i.ImageHeight,
 		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
 	)
 	return i, err
 }
 
-const deleteImage = `-- name: DeleteImage :exec
+const deleteImage = `-- name: DeleteImage :one
 DELETE FROM images
 WHERE id = $1
+RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
 `
 
-func (q *Queries) DeleteImage(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteImage, id)
-	return err
+func (q *Queries) DeleteImage(ctx context.Context, id uuid.UUID) (Image, error) {
+	row := q.db.QueryRow(ctx, deleteImage, id)
+	var i Image
+	err := row.Scan(
+		&i.ID,
+		&i.ArtworkID,
+		&i.IsMainImage,
+		&i.ObjectName,
+		&i.ImageUrl,
+		&i.ImageWidth,
+		&i.ImageHeight,
+		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
+	)
+	return i, err
 }
 
 const getImage = `-- name: GetImage :one
-SELECT id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at
+SELECT id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
 FROM images
 WHERE id = $1
 `
@@ -85,6 +172,269 @@ func (q *Queries) GetImage(ctx context.Context, id uuid.UUID) (Image, error) {
 		&i.ImageWidth,
 		&i.ImageHeight,
 		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
+	)
+	return i, err
+}
+
+const getImageForUpdate = `-- name: GetImageForUpdate :one
+SELECT id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
+FROM images
+WHERE id = $1 FOR
+UPDATE
+`
+
+func (q *Queries) GetImageForUpdate(ctx context.Context, id uuid.UUID) (Image, error) {
+	row := q.db.QueryRow(ctx, getImageForUpdate, id)
+	var i Image
+	err := row.Scan(
+		&i.ID,
+		&i.ArtworkID,
+		&i.IsMainImage,
+		&i.ObjectName,
+		&i.ImageUrl,
+		&i.ImageWidth,
+		&i.ImageHeight,
+		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
+	)
+	return i, err
+}
+
+const listImagePositionsForUpdate = `-- name: ListImagePositionsForUpdate :many
+SELECT id
+FROM images
+WHERE artwork_id = $1
+ORDER BY position,
+    created_at FOR
+UPDATE
+`
+
+func (q *Queries) ListImagePositionsForUpdate(ctx context.Context, artworkID pgtype.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listImagePositionsForUpdate, artworkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImages = `-- name: ListImages :many
+SELECT id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
+FROM images
+ORDER BY created_at
+`
+
+func (q *Queries) ListImages(ctx context.Context) ([]Image, error) {
+	rows, err := q.db.Query(ctx, listImages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Image
+	for rows.Next() {
+		var i Image
+		if err := rows.Scan(
+			&i.ID,
+			&i.ArtworkID,
+			&i.IsMainImage,
+			&i.ObjectName,
+			&i.ImageUrl,
+			&i.ImageWidth,
+			&i.ImageHeight,
+			&i.CreatedAt,
+			&i.Variants,
+			&i.Blurhash,
+			&i.DominantColor,
+			&i.Position,
+			&i.AltText,
+			&i.Caption,
+			&i.Credit,
+			&i.Phash,
+			&i.Palette,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArtworksWithMainImage = `-- name: ListArtworksWithMainImage :many
+SELECT DISTINCT artwork_id
+FROM images
+WHERE is_main_image = TRUE
+    AND artwork_id = ANY($1::uuid [])
+`
+
+func (q *Queries) ListArtworksWithMainImage(ctx context.Context, dollar_1 []uuid.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listArtworksWithMainImage, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var artwork_id pgtype.UUID
+		if err := rows.Scan(&artwork_id); err != nil {
+			return nil, err
+		}
+		items = append(items, artwork_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSimilarImages = `-- name: ListSimilarImages :many
+SELECT i.id,
+    i.artwork_id,
+    i.image_url,
+    i.object_name,
+    a.title AS artwork_title,
+    bit_count(int8send(i.phash # $1::bigint))::integer AS distance
+FROM images i
+    JOIN artworks a ON a.id = i.artwork_id
+WHERE i.phash IS NOT NULL
+    AND i.id <> $2
+    AND bit_count(int8send(i.phash # $1::bigint)) <= $3::integer
+ORDER BY distance,
+    i.created_at
+`
+
+type ListSimilarImagesParams struct {
+	Phash       int64     `db:"phash" json:"phash"`
+	ExcludeID   uuid.UUID `db:"exclude_id" json:"exclude_id"`
+	MaxDistance int32     `db:"max_distance" json:"max_distance"`
+}
+
+type ListSimilarImagesRow struct {
+	ID           uuid.UUID   `db:"id" json:"id"`
+	ArtworkID    pgtype.UUID `db:"artwork_id" json:"artwork_id"`
+	ImageUrl     string      `db:"image_url" json:"image_url"`
+	ObjectName   string      `db:"object_name" json:"object_name"`
+	ArtworkTitle string      `db:"artwork_title" json:"artwork_title"`
+	Distance     int32       `db:"distance" json:"distance"`
+}
+
+func (q *Queries) ListSimilarImages(ctx context.Context, arg ListSimilarImagesParams) ([]ListSimilarImagesRow, error) {
+	rows, err := q.db.Query(ctx, listSimilarImages, arg.Phash, arg.ExcludeID, arg.MaxDistance)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSimilarImagesRow
+	for rows.Next() {
+		var i ListSimilarImagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ArtworkID,
+			&i.ImageUrl,
+			&i.ObjectName,
+			&i.ArtworkTitle,
+			&i.Distance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replaceImageFile = `-- name: ReplaceImageFile :one
+UPDATE images
+SET object_name = $2,
+    image_url = $3,
+    image_width = $4,
+    image_height = $5,
+    variants = $6,
+    blurhash = $7,
+    dominant_color = $8,
+    phash = $9,
+    palette = $10
+WHERE id = $1
+RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
+`
+
+type ReplaceImageFileParams struct {
+	ID            uuid.UUID `db:"id" json:"id"`
+	ObjectName    string    `db:"object_name" json:"object_name"`
+	ImageUrl      string    `db:"image_url" json:"image_url"`
+	ImageWidth    *int32    `db:"image_width" json:"image_width"`
+	ImageHeight   *int32    `db:"image_height" json:"image_height"`
+	Variants      []byte    `db:"variants" json:"variants"`
+	Blurhash      *string   `db:"blurhash" json:"blurhash"`
+	DominantColor *string   `db:"dominant_color" json:"dominant_color"`
+	Phash         *int64    `db:"phash" json:"phash"`
+	Palette       []byte    `db:"palette" json:"palette"`
+}
+
+func (q *Queries) ReplaceImageFile(ctx context.Context, arg ReplaceImageFileParams) (Image, error) {
+	row := q.db.QueryRow(ctx, replaceImageFile,
+		arg.ID,
+		arg.ObjectName,
+		arg.ImageUrl,
+		arg.ImageWidth,
+		arg.ImageHeight,
+		arg.Variants,
+		arg.Blurhash,
+		arg.DominantColor,
+		arg.Phash,
+		arg.Palette,
+	)
+	var i Image
+	err := row.Scan(
+		&i.ID,
+		&i.ArtworkID,
+		&i.IsMainImage,
+		&i.ObjectName,
+		&i.ImageUrl,
+		&i.ImageWidth,
+		&i.ImageHeight,
+		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
 	)
 	return i, err
 }
@@ -110,18 +460,156 @@ func (q *Queries) SetMainImage(ctx context.Context, arg SetMainImageParams) erro
 
 const updateImage = `-- name: UpdateImage :one
 UPDATE images
-SET is_main_image = $2
+SET is_main_image = $2,
+    alt_text = $3,
+    caption = $4,
+    credit = $5
 WHERE id = $1
-RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at
+RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
 `
 
 type UpdateImageParams struct {
 	ID          uuid.UUID `db:"id" json:"id"`
 	IsMainImage bool      `db:"is_main_image" json:"is_main_image"`
+	AltText     *string   `db:"alt_text" json:"alt_text"`
+	Caption     *string   `db:"caption" json:"caption"`
+	Credit      *string   `db:"credit" json:"credit"`
 }
 
 func (q *Queries) UpdateImage(ctx context.Context, arg UpdateImageParams) (Image, error) {
-	row := q.db.QueryRow(ctx, updateImage, arg.ID, arg.IsMainImage)
+	row := q.db.QueryRow(ctx, updateImage,
+		arg.ID,
+		arg.IsMainImage,
+		arg.AltText,
+		arg.Caption,
+		arg.Credit,
+	)
+	var i Image
+	err := row.Scan(
+		&i.ID,
+		&i.ArtworkID,
+		&i.IsMainImage,
+		&i.ObjectName,
+		&i.ImageUrl,
+		&i.ImageWidth,
+		&i.ImageHeight,
+		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
+	)
+	return i, err
+}
+
+const updateImagePHash = `-- name: UpdateImagePHash :exec
+UPDATE images
+SET phash = $2
+WHERE id = $1
+`
+
+type UpdateImagePHashParams struct {
+	ID    uuid.UUID `db:"id" json:"id"`
+	Phash *int64    `db:"phash" json:"phash"`
+}
+
+func (q *Queries) UpdateImagePHash(ctx context.Context, arg UpdateImagePHashParams) error {
+	_, err := q.db.Exec(ctx, updateImagePHash, arg.ID, arg.Phash)
+	return err
+}
+
+const updateImagePalette = `-- name: UpdateImagePalette :exec
+UPDATE images
+SET palette = $2
+WHERE id = $1
+`
+
+type UpdateImagePaletteParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Palette []byte    `db:"palette" json:"palette"`
+}
+
+func (q *Queries) UpdateImagePalette(ctx context.Context, arg UpdateImagePaletteParams) error {
+	_, err := q.db.Exec(ctx, updateImagePalette, arg.ID, arg.Palette)
+	return err
+}
+
+const updateImagePositions = `-- name: UpdateImagePositions :exec
+UPDATE images
+SET position = v.position
+FROM unnest($2::uuid []) WITH ORDINALITY AS v (id, position)
+WHERE images.id = v.id
+    AND images.artwork_id = $1
+`
+
+type UpdateImagePositionsParams struct {
+	ArtworkID pgtype.UUID `db:"artwork_id" json:"artwork_id"`
+	Column2   []uuid.UUID `db:"column_2" json:"column_2"`
+}
+
+func (q *Queries) UpdateImagePositions(ctx context.Context, arg UpdateImagePositionsParams) error {
+	_, err := q.db.Exec(ctx, updateImagePositions, arg.ArtworkID, arg.Column2)
+	return err
+}
+
+const updateImageURLs = `-- name: UpdateImageURLs :one
+UPDATE images
+SET image_url = $2,
+    variants = $3
+WHERE id = $1
+RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
+`
+
+type UpdateImageURLsParams struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	ImageUrl string    `db:"image_url" json:"image_url"`
+	Variants []byte    `db:"variants" json:"variants"`
+}
+
+func (q *Queries) UpdateImageURLs(ctx context.Context, arg UpdateImageURLsParams) (Image, error) {
+	row := q.db.QueryRow(ctx, updateImageURLs, arg.ID, arg.ImageUrl, arg.Variants)
+	var i Image
+	err := row.Scan(
+		&i.ID,
+		&i.ArtworkID,
+		&i.IsMainImage,
+		&i.ObjectName,
+		&i.ImageUrl,
+		&i.ImageWidth,
+		&i.ImageHeight,
+		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
+	)
+	return i, err
+}
+
+const updateImageVariants = `-- name: UpdateImageVariants :one
+UPDATE images
+SET variants = $2
+WHERE id = $1
+RETURNING id, artwork_id, is_main_image, object_name, image_url, image_width, image_height, created_at, variants, blurhash, dominant_color, position, alt_text, caption, credit, phash, palette
+`
+
+type UpdateImageVariantsParams struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	Variants []byte    `db:"variants" json:"variants"`
+}
+
+func (q *Queries) UpdateImageVariants(ctx context.Context, arg UpdateImageVariantsParams) (Image, error) {
+	row := q.db.QueryRow(ctx, updateImageVariants, arg.ID, arg.Variants)
 	var i Image
 	err := row.Scan(
 		&i.ID,
@@ -132,6 +620,15 @@ func (q *Queries) UpdateImage(ctx context.Context, arg UpdateImageParams) (Image
 		&i.ImageWidth,
 		&i.ImageHeight,
 		&i.CreatedAt,
+		&i.Variants,
+		&i.Blurhash,
+		&i.DominantColor,
+		&i.Position,
+		&i.AltText,
+		&i.Caption,
+		&i.Credit,
+		&i.Phash,
+		&i.Palette,
 	)
 	return i, err
 }
@@ -256,17 +256,28 @@ type Artwork struct {
 	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
 	OrderID        pgtype.UUID      `db:"order_id" json:"order_id"`
 	Description    *string          `db:"description" json:"description"`
+	DepthInches    pgtype.Numeric   `db:"depth_inches" json:"depth_inches"`
+	WeightPounds   pgtype.Numeric   `db:"weight_pounds" json:"weight_pounds"`
 }
 
 type Image struct {
-	ID          uuid.UUID        `db:"id" json:"id"`
-	ArtworkID   pgtype.UUID      `db:"artwork_id" json:"artwork_id"`
-	IsMainImage bool             `db:"is_main_image" json:"is_main_image"`
-	ObjectName  string           `db:"object_name" json:"object_name"`
-	ImageUrl    string           `db:"image_url" json:"image_url"`
-	ImageWidth  *int32           `db:"image_width" json:"image_width"`
-	ImageHeight *int32           `db:"image_height" json:"image_height"`
-	CreatedAt   pgtype.Timestamp `db:"created_at" json:"created_at"`
+	ID            uuid.UUID        `db:"id" json:"id"`
+	ArtworkID     pgtype.UUID      `db:"artwork_id" json:"artwork_id"`
+	IsMainImage   bool             `db:"is_main_image" json:"is_main_image"`
+	ObjectName    string           `db:"object_name" json:"object_name"`
+	ImageUrl      string           `db:"image_url" json:"image_url"`
+	ImageWidth    *int32           `db:"image_width" json:"image_width"`
+	ImageHeight   *int32           `db:"image_height" json:"image_height"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	Variants      []byte           `db:"variants" json:"variants"`
+	Blurhash      *string          `db:"blurhash" json:"blurhash"`
+	DominantColor *string          `db:"dominant_color" json:"dominant_color"`
+	Position      int32            `db:"position" json:"position"`
+	AltText       *string          `db:"alt_text" json:"alt_text"`
+	Caption       *string          `db:"caption" json:"caption"`
+	Credit        *string          `db:"credit" json:"credit"`
+	Phash         *int64           `db:"phash" json:"phash"`
+	Palette       []byte           `db:"palette" json:"palette"`
 }
 
 type Order struct {
@@ -296,6 +307,17 @@ type PaymentRequirement struct {
 	Currency      string    `db:"currency" json:"currency"`
 }
 
+type PreviewLink struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	ArtworkIds   []uuid.UUID      `db:"artwork_ids" json:"artwork_ids"`
+	Label        *string          `db:"label" json:"label"`
+	ExpiresAt    pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	Revoked      bool             `db:"revoked" json:"revoked"`
+	ViewCount    int32            `db:"view_count" json:"view_count"`
+	LastViewedAt pgtype.Timestamp `db:"last_viewed_at" json:"last_viewed_at"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
 type RefreshToken struct {
 	ID        uuid.UUID        `db:"id" json:"id"`
 	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
@@ -320,6 +342,20 @@ type ShippingDetail struct {
 	Country string    `db:"country" json:"country"`
 }
 
+type StorageMigration struct {
+	Target     string           `db:"target" json:"target"`
+	ObjectName string           `db:"object_name" json:"object_name"`
+	Checksum   string           `db:"checksum" json:"checksum"`
+	Size       int64            `db:"size" json:"size"`
+	MigratedAt pgtype.Timestamp `db:"migrated_at" json:"migrated_at"`
+}
+
+type StorageObject struct {
+	ObjectName string           `db:"object_name" json:"object_name"`
+	RefCount   int32            `db:"ref_count" json:"ref_count"`
+	CreatedAt  pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
 type User struct {
 	ID           uuid.UUID        `db:"id" json:"id"`
 	Email        string           `db:"email" json:"email"`
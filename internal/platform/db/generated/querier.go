@@ -8,20 +8,30 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AcquireObjectReference(ctx context.Context, objectName string) error
+	ClearMainImage(ctx context.Context, artworkID pgtype.UUID) error
 	CreateArtwork(ctx context.Context, arg CreateArtworkParams) (Artwork, error)
+	CreateGlobalPaintingNumberUniqueIndex(ctx context.Context) error
 	CreateImage(ctx context.Context, arg CreateImageParams) (Image, error)
+	CreateMediumPaintingNumberUniqueIndex(ctx context.Context) error
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (CreateOrderRow, error)
 	CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error)
+	CreatePreviewLink(ctx context.Context, arg CreatePreviewLinkParams) (PreviewLink, error)
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateYearPaintingNumberUniqueIndex(ctx context.Context) error
 	DeleteArtwork(ctx context.Context, id uuid.UUID) error
 	DeleteExpiredRefreshTokens(ctx context.Context) error
-	DeleteImage(ctx context.Context, id uuid.UUID) error
+	DeleteImage(ctx context.Context, id uuid.UUID) (Image, error)
+	DeleteUnreferencedObject(ctx context.Context, objectName string) error
+	DropPaintingNumberUniqueIndexes(ctx context.Context) error
 	GetArtworkWithImages(ctx context.Context, id uuid.UUID) ([]GetArtworkWithImagesRow, error)
 	GetImage(ctx context.Context, id uuid.UUID) (Image, error)
+	GetImageForUpdate(ctx context.Context, id uuid.UUID) (Image, error)
 	GetOrder(ctx context.Context, id uuid.UUID) (Order, error)
 	GetOrderPaymentRequirement(ctx context.Context, orderID uuid.UUID) (PaymentRequirement, error)
 	GetOrderPayments(ctx context.Context, orderID uuid.UUID) ([]Payment, error)
@@ -30,20 +40,43 @@ type Querier interface {
 	GetRefreshTokenByJTI(ctx context.Context, jti uuid.UUID) (RefreshToken, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
+	IsObjectReferenced(ctx context.Context, objectName string) (bool, error)
+	ListArtworkSortOrdersForUpdate(ctx context.Context) ([]ListArtworkSortOrdersForUpdateRow, error)
 	ListArtworkStripeData(ctx context.Context, dollar_1 []uuid.UUID) ([]ListArtworkStripeDataRow, error)
 	ListArtworks(ctx context.Context, dollar_1 []string) ([]ListArtworksRow, error)
+	ListArtworksWithMainImage(ctx context.Context, dollar_1 []uuid.UUID) ([]pgtype.UUID, error)
+	ListCatalogNumbers(ctx context.Context) ([]ListCatalogNumbersRow, error)
+	ListImagePositionsForUpdate(ctx context.Context, artworkID pgtype.UUID) ([]uuid.UUID, error)
+	ListImages(ctx context.Context) ([]Image, error)
+	ListMigratedObjects(ctx context.Context, target string) ([]ListMigratedObjectsRow, error)
 	ListOrders(ctx context.Context, dollar_1 []string) ([]Order, error)
+	ListPaintingNumberIndexes(ctx context.Context) ([]string, error)
 	ListPaymentRequirements(ctx context.Context, dollar_1 []uuid.UUID) ([]PaymentRequirement, error)
 	ListPayments(ctx context.Context, dollar_1 []uuid.UUID) ([]Payment, error)
+	ListPreviewLinks(ctx context.Context) ([]PreviewLink, error)
 	ListShippingDetails(ctx context.Context, dollar_1 []uuid.UUID) ([]ShippingDetail, error)
+	ListSimilarImages(ctx context.Context, arg ListSimilarImagesParams) ([]ListSimilarImagesRow, error)
+	LockCatalogNumbers(ctx context.Context) error
+	LockObjectName(ctx context.Context, objectName string) error
+	RecordMigratedObject(ctx context.Context, arg RecordMigratedObjectParams) error
+	RecordPreviewLinkView(ctx context.Context, id uuid.UUID) (PreviewLink, error)
+	ReleaseObjectReference(ctx context.Context, objectName string) (int32, error)
+	ReplaceImageFile(ctx context.Context, arg ReplaceImageFileParams) (Image, error)
 	RevokeAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	RevokePreviewLink(ctx context.Context, id uuid.UUID) (int64, error)
 	RevokeRefreshToken(ctx context.Context, id uuid.UUID) error
 	RevokeSessionRefreshTokens(ctx context.Context, sessionID uuid.UUID) error
 	SelectArtworksForUpdate(ctx context.Context, dollar_1 []uuid.UUID) ([]Artwork, error)
 	SetMainImage(ctx context.Context, arg SetMainImageParams) error
 	UpdateArtwork(ctx context.Context, arg UpdateArtworkParams) (Artwork, error)
+	UpdateArtworkSortOrders(ctx context.Context, arg UpdateArtworkSortOrdersParams) error
 	UpdateArtworksAsPurchased(ctx context.Context, arg UpdateArtworksAsPurchasedParams) ([]Artwork, error)
 	UpdateImage(ctx context.Context, arg UpdateImageParams) (Image, error)
+	UpdateImagePHash(ctx context.Context, arg UpdateImagePHashParams) error
+	UpdateImagePalette(ctx context.Context, arg UpdateImagePaletteParams) error
+	UpdateImagePositions(ctx context.Context, arg UpdateImagePositionsParams) error
+	UpdateImageURLs(ctx context.Context, arg UpdateImageURLsParams) (Image, error)
+	UpdateImageVariants(ctx context.Context, arg UpdateImageVariantsParams) (Image, error)
 	UpdateOrderAndShipping(ctx context.Context, arg UpdateOrderAndShippingParams) (UpdateOrderAndShippingRow, error)
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
 	UpdateOrderStripeSessionID(ctx context.Context, arg UpdateOrderStripeSessionIDParams) error
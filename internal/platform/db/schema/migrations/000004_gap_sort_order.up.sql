@@ -0,0 +1,11 @@
+UPDATE artworks
+SET sort_order = ranked.position * 1000
+FROM (
+        SELECT id,
+            row_number() OVER (
+                ORDER BY sort_order,
+                    created_at DESC
+            ) AS position
+        FROM artworks
+    ) ranked
+WHERE artworks.id = ranked.id;
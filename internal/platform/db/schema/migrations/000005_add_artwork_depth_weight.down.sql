@@ -0,0 +1,3 @@
+ALTER TABLE artworks DROP COLUMN weight_pounds;
+
+ALTER TABLE artworks DROP COLUMN depth_inches;
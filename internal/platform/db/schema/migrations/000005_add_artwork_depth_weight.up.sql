@@ -0,0 +1,5 @@
+ALTER TABLE artworks
+ADD COLUMN depth_inches DECIMAL(8, 4);
+
+ALTER TABLE artworks
+ADD COLUMN weight_pounds DECIMAL(8, 4);
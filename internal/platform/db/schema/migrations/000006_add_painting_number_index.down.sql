@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_artworks_painting_number_global,
+idx_artworks_painting_number_year,
+idx_artworks_painting_number_medium;
@@ -0,0 +1,4 @@
+CREATE UNIQUE INDEX idx_artworks_painting_number_global ON artworks (
+    painting_number
+)
+WHERE painting_number IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_preview_links_expires_at;
+
+DROP TABLE IF EXISTS preview_links;
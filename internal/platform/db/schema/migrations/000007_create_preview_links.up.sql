@@ -0,0 +1,12 @@
+CREATE TABLE preview_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    artwork_ids UUID [] NOT NULL,
+    label TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_preview_links_expires_at ON preview_links (expires_at);
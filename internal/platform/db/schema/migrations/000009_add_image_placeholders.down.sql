@@ -0,0 +1,3 @@
+ALTER TABLE images DROP COLUMN dominant_color;
+
+ALTER TABLE images DROP COLUMN blurhash;
@@ -0,0 +1,5 @@
+ALTER TABLE images
+ADD COLUMN blurhash TEXT;
+
+ALTER TABLE images
+ADD COLUMN dominant_color CHAR(7);
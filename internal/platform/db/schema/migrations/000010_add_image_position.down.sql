@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_images_one_main_per_artwork;
+
+DROP INDEX IF EXISTS idx_images_artwork_id_position;
+
+ALTER TABLE images DROP COLUMN position;
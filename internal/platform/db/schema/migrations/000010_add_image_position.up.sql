@@ -0,0 +1,30 @@
+ALTER TABLE images
+ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE images
+SET position = ranked.position
+FROM (
+        SELECT id,
+            row_number() OVER (
+                PARTITION BY artwork_id
+                ORDER BY created_at
+            ) AS position
+        FROM images
+    ) ranked
+WHERE images.id = ranked.id;
+
+UPDATE images
+SET is_main_image = FALSE
+WHERE is_main_image
+    AND id NOT IN (
+        SELECT DISTINCT ON (artwork_id) id
+        FROM images
+        WHERE is_main_image
+        ORDER BY artwork_id,
+            created_at
+    );
+
+CREATE INDEX idx_images_artwork_id_position ON images (artwork_id, position);
+
+CREATE UNIQUE INDEX idx_images_one_main_per_artwork ON images (artwork_id)
+WHERE is_main_image;
@@ -0,0 +1,5 @@
+ALTER TABLE images DROP COLUMN credit;
+
+ALTER TABLE images DROP COLUMN caption;
+
+ALTER TABLE images DROP COLUMN alt_text;
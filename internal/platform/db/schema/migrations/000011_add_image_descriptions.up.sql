@@ -0,0 +1,8 @@
+ALTER TABLE images
+ADD COLUMN alt_text TEXT;
+
+ALTER TABLE images
+ADD COLUMN caption TEXT;
+
+ALTER TABLE images
+ADD COLUMN credit TEXT;
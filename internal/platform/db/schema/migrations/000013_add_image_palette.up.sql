@@ -0,0 +1,2 @@
+ALTER TABLE images
+ADD COLUMN palette JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,8 @@
+CREATE TABLE storage_migrations (
+    target TEXT NOT NULL,
+    object_name TEXT NOT NULL,
+    checksum TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    migrated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (target, object_name)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE storage_objects (
+    object_name TEXT PRIMARY KEY,
+    ref_count INTEGER NOT NULL CHECK (ref_count >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+INSERT INTO storage_objects (object_name, ref_count)
+SELECT object_name,
+    count(*)
+FROM images
+GROUP BY object_name;
@@ -5,6 +5,8 @@ INSERT INTO artworks (
         painting_year,
         width_inches,
         height_inches,
+        depth_inches,
+        weight_pounds,
         price_cents,
         description,
         paper,
@@ -23,7 +25,9 @@ VALUES (
         $8,
         $9,
         $10,
-        $11
+        $11,
+        $12,
+        $13
     )
 RETURNING *;
 
@@ -34,6 +38,13 @@ SELECT a.*,
     COALESCE(i.image_url, '') as image_url,
     i.image_width,
     i.image_height,
+    i.variants,
+    i.blurhash,
+    i.dominant_color,
+    i.palette,
+    i.alt_text,
+    i.caption,
+    i.credit,
     i.image_created_at
 FROM artworks a
     LEFT JOIN LATERAL (
@@ -42,10 +53,18 @@ FROM artworks a
             image_url,
             image_width,
             image_height,
+            variants,
+            blurhash,
+            dominant_color,
+            palette,
+            alt_text,
+            caption,
+            credit,
             created_at as image_created_at
         FROM images
         WHERE artwork_id = a.id
         ORDER BY is_main_image DESC NULLS LAST,
+            position,
             created_at
         LIMIT 1
     ) i ON true
@@ -58,16 +77,23 @@ ORDER BY a.sort_order,
 -- name: ListArtworkStripeData :many
 SELECT a.id,
     a.title,
+    a.width_inches,
+    a.height_inches,
+    a.depth_inches,
+    a.weight_pounds,
     a.price_cents,
     a.status,
     i.*
 FROM artworks a
     LEFT JOIN LATERAL (
         SELECT id as image_id,
-            image_url
+            image_url,
+            object_name,
+            variants
         FROM images
         WHERE artwork_id = a.id
         ORDER BY is_main_image DESC NULLS LAST,
+            position,
             created_at
         LIMIT 1
     ) i ON true
@@ -82,11 +108,20 @@ SELECT a.*,
     i.image_url,
     i.image_width,
     i.image_height,
+    i.variants,
+    i.blurhash,
+    i.dominant_color,
+    i.palette,
+    i.alt_text,
+    i.caption,
+    i.credit,
+    i.position,
     i.created_at as image_created_at
 FROM artworks a
     LEFT JOIN images i ON a.id = i.artwork_id
 WHERE a.id = $1
-ORDER BY i.created_at;
+ORDER BY i.position,
+    i.created_at;
 
 -- name: UpdateArtwork :one
 UPDATE artworks
@@ -95,16 +130,72 @@ SET title = $2,
     painting_year = $4,
     width_inches = $5,
     height_inches = $6,
-    price_cents = $7,
-    description = $8,
-    paper = $9,
-    sort_order = $10,
-    status = $11,
-    medium = $12,
-    category = $13
+    depth_inches = $7,
+    weight_pounds = $8,
+    price_cents = $9,
+    description = $10,
+    paper = $11,
+    sort_order = $12,
+    status = $13,
+    medium = $14,
+    category = $15
 WHERE id = $1
 RETURNING *;
 
+-- name: LockCatalogNumbers :exec
+SELECT pg_advisory_xact_lock(hashtext('artworks.painting_number'));
+
+-- name: ListPaintingNumberIndexes :many
+SELECT indexname::text
+FROM pg_indexes
+WHERE tablename = 'artworks'
+    AND indexname LIKE 'idx_artworks_painting_number_%'
+ORDER BY indexname;
+
+-- name: DropPaintingNumberUniqueIndexes :exec
+DROP INDEX IF EXISTS idx_artworks_painting_number_global,
+idx_artworks_painting_number_year,
+idx_artworks_painting_number_medium;
+
+-- name: CreateGlobalPaintingNumberUniqueIndex :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artworks_painting_number_global
+ON artworks (painting_number)
+WHERE painting_number IS NOT NULL;
+
+-- name: CreateYearPaintingNumberUniqueIndex :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artworks_painting_number_year
+ON artworks (coalesce(painting_year, -1), painting_number)
+WHERE painting_number IS NOT NULL;
+
+-- name: CreateMediumPaintingNumberUniqueIndex :exec
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artworks_painting_number_medium
+ON artworks (medium, painting_number)
+WHERE painting_number IS NOT NULL;
+
+-- name: ListCatalogNumbers :many
+SELECT id,
+    title,
+    painting_number,
+    painting_year,
+    medium
+FROM artworks
+ORDER BY painting_number NULLS LAST,
+    created_at;
+
+-- name: ListArtworkSortOrdersForUpdate :many
+SELECT id,
+    sort_order
+FROM artworks
+ORDER BY sort_order,
+    created_at DESC FOR
+UPDATE;
+
+-- name: UpdateArtworkSortOrders :exec
+UPDATE artworks
+SET sort_order = ($2::integer [])[v.position]
+FROM unnest($1::uuid []) WITH ORDINALITY AS v (id, position)
+WHERE artworks.id = v.id;
+
 -- name: SelectArtworksForUpdate :many
 SELECT *
 FROM artworks
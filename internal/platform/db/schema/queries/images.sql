@@ -5,9 +5,38 @@ INSERT INTO images (
         image_url,
         is_main_image,
         image_width,
-        image_height
+        image_height,
+        variants,
+        blurhash,
+        dominant_color,
+        alt_text,
+        caption,
+        credit,
+        phash,
+        palette,
+        position
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13,
+        $14,
+        (
+            SELECT COALESCE(MAX(position), 0) + 1
+            FROM images
+            WHERE artwork_id = $1
+        )
     )
-VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetImage :one
@@ -15,15 +44,51 @@ SELECT *
 FROM images
 WHERE id = $1;
 
+-- name: GetImageForUpdate :one
+SELECT *
+FROM images
+WHERE id = $1 FOR
+UPDATE;
+
+-- name: ListImages :many
+SELECT *
+FROM images
+ORDER BY created_at;
+
+-- name: ListArtworksWithMainImage :many
+SELECT DISTINCT artwork_id
+FROM images
+WHERE is_main_image = TRUE
+    AND artwork_id = ANY($1::uuid []);
+
+-- name: ListSimilarImages :many
+SELECT i.id,
+    i.artwork_id,
+    i.image_url,
+    i.object_name,
+    a.title AS artwork_title,
+    bit_count(int8send(i.phash # sqlc.arg(phash)::bigint))::integer AS distance
+FROM images i
+    JOIN artworks a ON a.id = i.artwork_id
+WHERE i.phash IS NOT NULL
+    AND i.id <> sqlc.arg(exclude_id)
+    AND bit_count(int8send(i.phash # sqlc.arg(phash)::bigint)) <= sqlc.arg(max_distance)::integer
+ORDER BY distance,
+    i.created_at;
+
 -- name: UpdateImage :one
 UPDATE images
-SET is_main_image = $2
+SET is_main_image = $2,
+    alt_text = $3,
+    caption = $4,
+    credit = $5
 WHERE id = $1
 RETURNING *;
 
--- name: DeleteImage :exec
+-- name: DeleteImage :one
 DELETE FROM images
-WHERE id = $1;
+WHERE id = $1
+RETURNING *;
 
 -- name: SetMainImage :exec
 UPDATE images
@@ -31,4 +96,64 @@ SET is_main_image = CASE
         WHEN id = $1 THEN TRUE
         ELSE FALSE
     END
-WHERE artwork_id = $2;
\ No newline at end of file
+WHERE artwork_id = $2;
+
+-- name: ClearMainImage :exec
+UPDATE images
+SET is_main_image = FALSE
+WHERE artwork_id = $1
+    AND is_main_image;
+
+-- name: ListImagePositionsForUpdate :many
+SELECT id
+FROM images
+WHERE artwork_id = $1
+ORDER BY position,
+    created_at FOR
+UPDATE;
+
+-- name: UpdateImagePositions :exec
+UPDATE images
+SET position = v.position
+FROM unnest($2::uuid []) WITH ORDINALITY AS v (id, position)
+WHERE images.id = v.id
+    AND images.artwork_id = $1;
+
+-- name: UpdateImagePHash :exec
+UPDATE images
+SET phash = $2
+WHERE id = $1;
+
+-- name: UpdateImagePalette :exec
+UPDATE images
+SET palette = $2
+WHERE id = $1;
+
+
+-- name: UpdateImageVariants :one
+UPDATE images
+SET variants = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateImageURLs :one
+UPDATE images
+SET image_url = $2,
+    variants = $3
+WHERE id = $1
+RETURNING *;
+
+
+-- name: ReplaceImageFile :one
+UPDATE images
+SET object_name = $2,
+    image_url = $3,
+    image_width = $4,
+    image_height = $5,
+    variants = $6,
+    blurhash = $7,
+    dominant_color = $8,
+    phash = $9,
+    palette = $10
+WHERE id = $1
+RETURNING *;
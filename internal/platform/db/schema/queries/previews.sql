@@ -0,0 +1,23 @@
+-- name: CreatePreviewLink :one
+INSERT INTO preview_links (artwork_ids, label, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListPreviewLinks :many
+SELECT *
+FROM preview_links
+ORDER BY created_at DESC;
+
+-- name: RecordPreviewLinkView :one
+UPDATE preview_links
+SET view_count = view_count + 1,
+    last_viewed_at = current_timestamp
+WHERE id = $1
+    AND revoked = FALSE
+    AND expires_at > NOW()
+RETURNING *;
+
+-- name: RevokePreviewLink :execrows
+UPDATE preview_links
+SET revoked = TRUE
+WHERE id = $1;
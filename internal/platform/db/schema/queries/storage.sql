@@ -0,0 +1,40 @@
+-- name: ListMigratedObjects :many
+SELECT object_name,
+    checksum
+FROM storage_migrations
+WHERE target = $1;
+
+-- name: RecordMigratedObject :exec
+INSERT INTO storage_migrations (target, object_name, checksum, size)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (target, object_name) DO UPDATE
+SET checksum = EXCLUDED.checksum,
+    size = EXCLUDED.size,
+    migrated_at = current_timestamp;
+
+-- name: LockObjectName :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg(object_name)::text));
+
+-- name: AcquireObjectReference :exec
+INSERT INTO storage_objects (object_name, ref_count)
+VALUES ($1, 1)
+ON CONFLICT (object_name) DO UPDATE
+SET ref_count = storage_objects.ref_count + 1;
+
+-- name: ReleaseObjectReference :one
+UPDATE storage_objects
+SET ref_count = ref_count - 1
+WHERE object_name = $1
+RETURNING ref_count;
+
+-- name: IsObjectReferenced :one
+SELECT EXISTS (
+        SELECT 1
+        FROM storage_objects
+        WHERE object_name = $1
+    );
+
+-- name: DeleteUnreferencedObject :exec
+DELETE FROM storage_objects
+WHERE object_name = $1
+    AND ref_count = 0;
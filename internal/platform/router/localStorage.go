@@ -1,6 +1,7 @@
 package router
 
 import (
+	"io"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -29,6 +30,66 @@ func serveLocalStorage(localStorage *storage.LocalStorage) http.Handler {
 	})
 }
 
+func receiveLocalUploads(localStorage *storage.LocalStorage) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !config.IsDebug() || r.Method != http.MethodPut {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		objectName := query.Get("object")
+		contentType := query.Get("content_type")
+
+		err := localStorage.VerifyUploadSignature(objectName, contentType, query.Get("expires"), query.Get("signature"))
+		if err != nil || r.Header.Get("Content-Type") != contentType {
+			http.Error(w, "invalid upload signature", http.StatusForbidden)
+			return
+		}
+		if strings.Contains(objectName, "..") {
+			http.Error(w, "invalid object name", http.StatusBadRequest)
+			return
+		}
+
+		if err := localStorage.UploadObject(r.Context(), objectName, contentType, r.Body); err != nil {
+			http.Error(w, "upload failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func serveSignedLocalObjects(localStorage *storage.LocalStorage) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !config.IsDebug() || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		objectName := query.Get("object")
+
+		if err := localStorage.VerifyReadSignature(objectName, query.Get("expires"), query.Get("signature")); err != nil {
+			http.Error(w, "invalid download signature", http.StatusForbidden)
+			return
+		}
+
+		file, err := localStorage.GetObject(r.Context(), objectName)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+
+		if contentType := getContentType(filepath.Ext(objectName)); contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Header().Set("Cache-Control", "private, no-store")
+		io.Copy(w, file)
+	})
+}
+
 func getContentType(ext string) string {
 	switch strings.ToLower(ext) {
 	case ".jpg", ".jpeg":
@@ -33,11 +33,13 @@ func New(db *store.Store, provider storage.Provider, config *config.Config, mail
 	}
 }
 
-func (s *RouterService) CreateRouter() *chi.Mux {
+func (s *RouterService) CreateRouter() (*chi.Mux, error) {
 	r := chi.NewRouter()
 	s.registerMiddleware(r)
-	s.registerRoutes(r)
-	return r
+	if err := s.registerRoutes(r); err != nil {
+		return nil, err
+	}
+	return r, nil
 }
 
 func (s *RouterService) registerMiddleware(r *chi.Mux) {
@@ -73,17 +75,32 @@ func securityHeaders(next http.Handler) http.Handler {
 	})
 }
 
-func (s *RouterService) registerRoutes(r *chi.Mux) {
+func (s *RouterService) registerRoutes(r *chi.Mux) error {
 	authHandler := auth.New(s.db, s.config)
 	r.Mount("/auth", authHandler.Routes())
 
-	artworkHandler := artwork.NewArtworkHandler(s.db, s.provider, s.config)
+	artworkHandler, err := artwork.NewArtworkHandler(s.db, s.provider, s.config)
+	if err != nil {
+		return err
+	}
 	r.Mount("/artworks", artworkHandler.Routes())
 
-	imageHandler := artwork.NewImageHandler(s.db, s.provider, s.config)
+	imageHandler, err := artwork.NewImageHandler(s.db, s.provider, s.config)
+	if err != nil {
+		return err
+	}
 	imagesRoute := fmt.Sprintf("/artworks/{%s}/images", artwork.ArtworkIDParam)
 	r.Mount(imagesRoute, imageHandler.Routes())
 
+	resizeHandler, err := artwork.NewResizeHandler(s.db, s.provider, s.config)
+	if err != nil {
+		return err
+	}
+	r.Mount("/img", resizeHandler.Routes())
+
+	previewHandler := artwork.NewPreviewHandler(s.db, s.config)
+	r.Mount("/previews", previewHandler.Routes())
+
 	ordersHandler := payments.NewOrdersHandler(s.db, s.config, s.mailer)
 	r.Mount("/orders", ordersHandler.Routes())
 
@@ -96,6 +113,10 @@ func (s *RouterService) registerRoutes(r *chi.Mux) {
 	if config.IsDebug() {
 		if localStorage, ok := s.provider.(*storage.LocalStorage); ok {
 			r.Mount("/uploads", serveLocalStorage(localStorage))
+			r.Mount(storage.LocalUploadPath, receiveLocalUploads(localStorage))
+			r.Mount(storage.LocalDownloadPath, serveSignedLocalObjects(localStorage))
 		}
 	}
+
+	return nil
 }
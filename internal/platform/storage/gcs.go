@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,17 +19,18 @@ import (
 type GCS struct {
 	bucketName  string
 	tokenSource oauth2.TokenSource
+	signer      *gcsSigner
 }
 
 func NewGCS(bucketName string) *GCS {
 	ctx := context.Background()
 
-	tokenSource, err := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/devstorage.full_control")
+	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/devstorage.full_control")
 	if err != nil {
-		return &GCS{bucketName: bucketName, tokenSource: nil}
+		return &GCS{bucketName: bucketName, tokenSource: nil, signer: newGCSSigner(nil)}
 	}
 
-	return &GCS{bucketName: bucketName, tokenSource: tokenSource}
+	return &GCS{bucketName: bucketName, tokenSource: creds.TokenSource, signer: newGCSSigner(creds.JSON)}
 }
 
 func (s *GCS) Close() {
@@ -48,7 +50,7 @@ func (s *GCS) GetObjectURL(objectName string) string {
 	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", s.bucketName, escaped)
 }
 
-func (s *GCS) UploadObject(objectName, contentType string, file io.Reader) error {
+func (s *GCS) UploadObject(ctx context.Context, objectName, contentType string, file io.Reader) error {
 	token, err := s.getAccessToken()
 	if err != nil {
 		return err
@@ -57,7 +59,7 @@ func (s *GCS) UploadObject(objectName, contentType string, file io.Reader) error
 	encodedName := url.QueryEscape(objectName)
 	url := fmt.Sprintf("https://storage.googleapis.com/upload/storage/v1/b/%s/o?uploadType=media&name=%s", s.bucketName, encodedName)
 
-	req, err := http.NewRequest("POST", url, file)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, file)
 	if err != nil {
 		return err
 	}
@@ -78,7 +80,205 @@ func (s *GCS) UploadObject(objectName, contentType string, file io.Reader) error
 	return nil
 }
 
-func (s *GCS) DeleteObject(objectName string) error {
+func (s *GCS) GetObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	token, err := s.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	encodedName := url.QueryEscape(objectName)
+	url := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s?alt=media", s.bucketName, encodedName)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get failed: %s -- %s", resp.Status, body)
+	}
+
+	return resp.Body, nil
+}
+
+func (s *GCS) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	token, err := s.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []ObjectInfo{}
+	pageToken := ""
+
+	for {
+		query := url.Values{}
+		query.Set("prefix", prefix)
+		query.Set("fields", "items(name,size,contentType,updated),nextPageToken")
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		url := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o?%s", s.bucketName, query.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		page, err := s.fetchObjectPage(req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			objects = append(objects, item.info())
+		}
+
+		if page.NextPageToken == "" {
+			return objects, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+type gcsObject struct {
+	Name        string    `json:"name"`
+	Size        string    `json:"size"`
+	ContentType string    `json:"contentType"`
+	Updated     time.Time `json:"updated"`
+}
+
+func (o *gcsObject) info() ObjectInfo {
+	size, _ := strconv.ParseInt(o.Size, 10, 64)
+	return ObjectInfo{
+		Name:        o.Name,
+		Size:        size,
+		ContentType: o.ContentType,
+		UpdatedAt:   o.Updated,
+	}
+}
+
+type gcsObjectPage struct {
+	Items         []gcsObject `json:"items"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+func (s *GCS) fetchObjectPage(req *http.Request) (*gcsObjectPage, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list failed: %s -- %s", resp.Status, body)
+	}
+
+	var page gcsObjectPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (s *GCS) SignedUploadURL(ctx context.Context, objectName, contentType string, expiresAt time.Time) (string, error) {
+	return s.signURL(ctx, "PUT", objectName, contentType, expiresAt)
+}
+
+func (s *GCS) SignedReadURL(ctx context.Context, objectName string, expiresAt time.Time) (string, error) {
+	return s.signURL(ctx, "GET", objectName, "", expiresAt)
+}
+
+func (s *GCS) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	token, err := s.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	encodedName := url.QueryEscape(objectName)
+	url := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s?fields=name,size,contentType,updated", s.bucketName, encodedName)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("stat failed: %s -- %s", resp.Status, body)
+	}
+
+	var object gcsObject
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return nil, err
+	}
+
+	info := object.info()
+	return &info, nil
+}
+
+func (s *GCS) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	return existsFromStat(s.StatObject(ctx, objectName))
+}
+
+func (s *GCS) CopyObject(ctx context.Context, srcName, dstName string) error {
+	token, err := s.getAccessToken()
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf(
+		"https://storage.googleapis.com/storage/v1/b/%s/o/%s/copyTo/b/%s/o/%s",
+		s.bucketName, url.QueryEscape(srcName), s.bucketName, url.QueryEscape(dstName),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("copy failed: %s -- %s", resp.Status, body)
+	}
+
+	return nil
+}
+
+func (s *GCS) DeleteObject(ctx context.Context, objectName string) error {
 	token, err := s.getAccessToken()
 	if err != nil {
 		return err
@@ -87,7 +287,7 @@ func (s *GCS) DeleteObject(objectName string) error {
 	encodedName := url.QueryEscape(objectName)
 	url := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s", s.bucketName, encodedName)
 
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return err
 	}
@@ -99,6 +299,9 @@ func (s *GCS) DeleteObject(objectName string) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
 	if resp.StatusCode/100 != 2 {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("delete failed: %s -- %s", resp.Status, body)
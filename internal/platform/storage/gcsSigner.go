@@ -0,0 +1,261 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gcsHost            = "storage.googleapis.com"
+	gcsSigningAlgo     = "GOOG4-RSA-SHA256"
+	gcsMaxSignedExpiry = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidSigningKey = errors.New("invalid signing key")
+)
+
+type gcsSigner struct {
+	email      string
+	privateKey *rsa.PrivateKey
+	mu         sync.Mutex
+}
+
+func newGCSSigner(credentialsJSON []byte) *gcsSigner {
+	signer := &gcsSigner{}
+	if len(credentialsJSON) == 0 {
+		return signer
+	}
+
+	var creds struct {
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	if err := json.Unmarshal(credentialsJSON, &creds); err != nil || creds.PrivateKey == "" {
+		return signer
+	}
+
+	key, err := parseRSAPrivateKey(creds.PrivateKey)
+	if err != nil {
+		return signer
+	}
+
+	signer.email = creds.ClientEmail
+	signer.privateKey = key
+	return signer
+}
+
+func parseRSAPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, ErrInvalidSigningKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidSigningKey
+	}
+	return key, nil
+}
+
+func (s *GCS) signURL(ctx context.Context, method, objectName, contentType string, expiresAt time.Time) (string, error) {
+	now := time.Now().UTC()
+	expires := expiresAt.Sub(now)
+	if expires <= 0 || expires > gcsMaxSignedExpiry {
+		return "", fmt.Errorf("signed url expiry must be between 1s and %s", gcsMaxSignedExpiry)
+	}
+
+	email, err := s.signerEmail(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	datestamp := now.Format("20060102")
+	timestamp := now.Format("20060102T150405Z")
+	scope := fmt.Sprintf("%s/auto/storage/goog4_request", datestamp)
+
+	signedHeaders := "host"
+	headers := fmt.Sprintf("host:%s\n", gcsHost)
+	if contentType != "" {
+		signedHeaders = "content-type;host"
+		headers = fmt.Sprintf("content-type:%s\nhost:%s\n", contentType, gcsHost)
+	}
+
+	query := map[string]string{
+		"X-Goog-Algorithm":     gcsSigningAlgo,
+		"X-Goog-Credential":    email + "/" + scope,
+		"X-Goog-Date":          timestamp,
+		"X-Goog-Expires":       fmt.Sprintf("%d", int(expires.Seconds())),
+		"X-Goog-SignedHeaders": signedHeaders,
+	}
+	canonicalQuery := canonicalQueryString(query)
+	canonicalPath := "/" + s.bucketName + "/" + escapeObjectPath(objectName)
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalPath,
+		canonicalQuery,
+		headers,
+		signedHeaders,
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		gcsSigningAlgo,
+		timestamp,
+		scope,
+		hex.EncodeToString(hashed[:]),
+	}, "\n")
+
+	signature, err := s.signBytes(ctx, []byte(stringToSign))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://%s%s?%s&X-Goog-Signature=%s", gcsHost, canonicalPath, canonicalQuery, hex.EncodeToString(signature)), nil
+}
+
+func (s *GCS) signerEmail(ctx context.Context) (string, error) {
+	s.signer.mu.Lock()
+	defer s.signer.mu.Unlock()
+
+	if s.signer.email != "" {
+		return s.signer.email, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/email", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("get service account email failed: %s -- %s", resp.Status, body)
+	}
+
+	s.signer.email = strings.TrimSpace(string(body))
+	return s.signer.email, nil
+}
+
+func (s *GCS) signBytes(ctx context.Context, payload []byte) ([]byte, error) {
+	if s.signer.privateKey != nil {
+		hashed := sha256.Sum256(payload)
+		return rsa.SignPKCS1v15(rand.Reader, s.signer.privateKey, crypto.SHA256, hashed[:])
+	}
+	return s.signBlob(ctx, payload)
+}
+
+func (s *GCS) signBlob(ctx context.Context, payload []byte) ([]byte, error) {
+	token, err := s.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := s.signerEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody, err := json.Marshal(map[string]string{"payload": base64.StdEncoding.EncodeToString(payload)})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:signBlob", url.PathEscape(email))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("sign blob failed: %s -- %s", resp.Status, body)
+	}
+
+	var data struct {
+		SignedBlob string `json:"signedBlob"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(data.SignedBlob)
+}
+
+func canonicalQueryString(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = uriEncode(key) + "=" + uriEncode(query[key])
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapeObjectPath(objectName string) string {
+	segments := strings.Split(objectName, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func uriEncode(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,20 @@
+package storage_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/art-vbst/art-backend/internal/platform/storage/storagetest"
+)
+
+// TestGCS runs against a real bucket using application default credentials.
+// Set STORAGETEST_GCS_BUCKET to enable it.
+func TestGCS(t *testing.T) {
+	bucket := os.Getenv("STORAGETEST_GCS_BUCKET")
+	if bucket == "" {
+		t.Skip("STORAGETEST_GCS_BUCKET not set")
+	}
+
+	storagetest.TestProvider(t, storage.NewGCS(bucket))
+}
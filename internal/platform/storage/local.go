@@ -1,23 +1,44 @@
 package storage
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/fs"
+	"mime"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
 
-const LocalStorageSubDir = "uploads"
+const (
+	LocalStorageSubDir = "uploads"
+	LocalUploadPath    = "/local-uploads"
+	LocalDownloadPath  = "/local-downloads"
+
+	localSigningLabel = "local-storage-url-signing"
+)
 
 type LocalStorage struct {
-	baseUrl string
-	dirName string
+	baseUrl    string
+	dirName    string
+	signingKey []byte
 }
 
-func NewLocalStorage(baseUrl string, dirName string) *LocalStorage {
-	return &LocalStorage{baseUrl: baseUrl, dirName: dirName}
+// NewLocalStorage derives its URL signing key from secret, so a signed URL
+// can never be mistaken for a token signed with the secret itself.
+func NewLocalStorage(baseUrl string, dirName string, secret string) *LocalStorage {
+	return &LocalStorage{
+		baseUrl:    baseUrl,
+		dirName:    dirName,
+		signingKey: hmacSHA256([]byte(secret), localSigningLabel),
+	}
 }
 
 func (s *LocalStorage) Close() {
@@ -36,29 +57,198 @@ func (s *LocalStorage) GetObjectURL(objectName string) string {
 	return fmt.Sprintf("%s/%s", s.baseUrl, objectName)
 }
 
-func (s *LocalStorage) UploadObject(objectName, contentType string, file io.Reader) error {
-	filePath := filepath.Join(s.dirName, objectName)
+func (s *LocalStorage) UploadObject(ctx context.Context, objectName, contentType string, file io.Reader) error {
+	filePath, err := s.objectPath(objectName)
+	if err != nil {
+		return err
+	}
 
 	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	out, err := os.Create(filePath)
+	out, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
+	defer os.Remove(out.Name())
 	defer out.Close()
 
-	_, err = io.Copy(out, file)
-	if err != nil {
+	if err := out.Chmod(0644); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(out, &contextReader{ctx: ctx, r: file}); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(out.Name(), filePath); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return nil
 }
 
-func (s *LocalStorage) DeleteObject(objectName string) error {
-	filePath := filepath.Join(s.dirName, objectName)
+func (s *LocalStorage) GetObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filePath, err := s.objectPath(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
+
+func (s *LocalStorage) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filePath, err := s.objectPath(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil || info.IsDir() {
+		if err == nil || os.IsNotExist(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return localObjectInfo(objectName, info), nil
+}
+
+func (s *LocalStorage) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	return existsFromStat(s.StatObject(ctx, objectName))
+}
+
+func (s *LocalStorage) CopyObject(ctx context.Context, srcName, dstName string) error {
+	src, err := s.GetObject(ctx, srcName)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return s.UploadObject(ctx, dstName, "", src)
+}
+
+func (s *LocalStorage) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+
+	err := filepath.WalkDir(s.dirName, func(filePath string, entry fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dirName, filePath)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, *localObjectInfo(name, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return objects, nil
+}
+
+func (s *LocalStorage) SignedUploadURL(ctx context.Context, objectName, contentType string, expiresAt time.Time) (string, error) {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("object", objectName)
+	query.Set("content_type", contentType)
+	query.Set("expires", expires)
+	query.Set("signature", s.signature("PUT", objectName, contentType, expires))
+
+	return fmt.Sprintf("%s%s?%s", s.baseUrl, LocalUploadPath, query.Encode()), nil
+}
+
+func (s *LocalStorage) SignedReadURL(ctx context.Context, objectName string, expiresAt time.Time) (string, error) {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("object", objectName)
+	query.Set("expires", expires)
+	query.Set("signature", s.signature("GET", objectName, "", expires))
+
+	return fmt.Sprintf("%s%s?%s", s.baseUrl, LocalDownloadPath, query.Encode()), nil
+}
+
+func (s *LocalStorage) VerifyUploadSignature(objectName, contentType, expires, signature string) error {
+	return s.verifySignature("PUT", objectName, contentType, expires, signature)
+}
+
+func (s *LocalStorage) VerifyReadSignature(objectName, expires, signature string) error {
+	return s.verifySignature("GET", objectName, "", expires, signature)
+}
+
+func (s *LocalStorage) verifySignature(method, objectName, contentType, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+
+	expected := s.signature(method, objectName, contentType, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) signature(method, objectName, contentType, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(method + "\n" + objectName + "\n" + contentType + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) DeleteObject(ctx context.Context, objectName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	filePath, err := s.objectPath(objectName)
+	if err != nil {
+		return err
+	}
 
 	if err := os.Remove(filePath); err != nil {
 		if os.IsNotExist(err) {
@@ -73,3 +263,19 @@ func (s *LocalStorage) DeleteObject(objectName string) error {
 func (s *LocalStorage) GetStorageDir() string {
 	return s.dirName
 }
+
+func (s *LocalStorage) objectPath(objectName string) (string, error) {
+	if objectName == "" || !filepath.IsLocal(filepath.FromSlash(objectName)) {
+		return "", ErrInvalidObjectName
+	}
+	return filepath.Join(s.dirName, filepath.FromSlash(objectName)), nil
+}
+
+func localObjectInfo(name string, info fs.FileInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Name:        name,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(name)),
+		UpdatedAt:   info.ModTime(),
+	}
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+	updatedAt   time.Time
+}
+
+type MemoryStorage struct {
+	baseUrl string
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+func NewMemoryStorage(baseUrl string) *MemoryStorage {
+	return &MemoryStorage{baseUrl: baseUrl, objects: map[string]memoryObject{}}
+}
+
+func (s *MemoryStorage) Close() {
+}
+
+func (s *MemoryStorage) GetObjectName(fileName string) string {
+	safe := sanitizeFileName(path.Base(strings.TrimSpace(fileName)))
+	if safe == "" {
+		safe = "file"
+	}
+	return fmt.Sprintf("uploads/%d-%s", time.Now().UnixNano(), safe)
+}
+
+func (s *MemoryStorage) GetObjectURL(objectName string) string {
+	return fmt.Sprintf("%s/%s", s.baseUrl, objectName)
+}
+
+func (s *MemoryStorage) UploadObject(ctx context.Context, objectName, contentType string, file io.Reader) error {
+	if objectName == "" {
+		return ErrInvalidObjectName
+	}
+
+	data, err := io.ReadAll(&contextReader{ctx: ctx, r: file})
+	if err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(objectName))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[objectName] = memoryObject{data: data, contentType: contentType, updatedAt: time.Now()}
+	return nil
+}
+
+func (s *MemoryStorage) GetObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	object, err := s.lookup(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(object.data)), nil
+}
+
+func (s *MemoryStorage) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	object, err := s.lookup(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return object.info(objectName), nil
+}
+
+func (s *MemoryStorage) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	return existsFromStat(s.StatObject(ctx, objectName))
+}
+
+func (s *MemoryStorage) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	objects := []ObjectInfo{}
+	for name, object := range s.objects {
+		if strings.HasPrefix(name, prefix) {
+			objects = append(objects, *object.info(name))
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+
+	return objects, nil
+}
+
+func (s *MemoryStorage) CopyObject(ctx context.Context, srcName, dstName string) error {
+	if dstName == "" {
+		return ErrInvalidObjectName
+	}
+
+	object, err := s.lookup(ctx, srcName)
+	if err != nil {
+		return err
+	}
+	object.updatedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[dstName] = object
+	return nil
+}
+
+func (s *MemoryStorage) SignedUploadURL(ctx context.Context, objectName, contentType string, expiresAt time.Time) (string, error) {
+	return fmt.Sprintf("%s/%s?expires=%d", s.baseUrl, objectName, expiresAt.Unix()), nil
+}
+
+func (s *MemoryStorage) SignedReadURL(ctx context.Context, objectName string, expiresAt time.Time) (string, error) {
+	return fmt.Sprintf("%s/%s?expires=%d", s.baseUrl, objectName, expiresAt.Unix()), nil
+}
+
+func (s *MemoryStorage) DeleteObject(ctx context.Context, objectName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, objectName)
+	return nil
+}
+
+func (s *MemoryStorage) lookup(ctx context.Context, objectName string) (memoryObject, error) {
+	if err := ctx.Err(); err != nil {
+		return memoryObject{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	object, ok := s.objects[objectName]
+	if !ok {
+		return memoryObject{}, ErrObjectNotFound
+	}
+	return object, nil
+}
+
+func (o memoryObject) info(name string) *ObjectInfo {
+	return &ObjectInfo{
+		Name:        name,
+		Size:        int64(len(o.data)),
+		ContentType: o.contentType,
+		UpdatedAt:   o.updatedAt,
+	}
+}
@@ -0,0 +1,12 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/art-vbst/art-backend/internal/platform/storage/storagetest"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	storagetest.TestProvider(t, storage.NewMemoryStorage("http://localhost:8080/uploads"))
+}
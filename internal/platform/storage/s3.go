@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/platform/config"
+)
+
+type S3 struct {
+	bucketName string
+	endpoint   *url.URL
+	pathStyle  bool
+	publicURL  string
+	signer     *s3Signer
+}
+
+func NewS3(env *config.Config) *S3 {
+	endpoint, _ := url.Parse(env.S3Endpoint)
+
+	return &S3{
+		bucketName: env.S3BucketName,
+		endpoint:   endpoint,
+		pathStyle:  env.S3PathStyle,
+		publicURL:  env.S3PublicURL,
+		signer: &s3Signer{
+			accessKeyID:     env.S3AccessKeyID,
+			secretAccessKey: env.S3SecretAccessKey,
+			region:          env.S3Region,
+		},
+	}
+}
+
+func (s *S3) Close() {
+}
+
+func (s *S3) GetObjectName(fileName string) string {
+	base := filepath.Base(strings.TrimSpace(fileName))
+	safe := sanitizeFileName(base)
+	if safe == "" {
+		safe = "file"
+	}
+	return fmt.Sprintf("uploads/%d-%s", time.Now().UnixNano(), safe)
+}
+
+func (s *S3) GetObjectURL(objectName string) string {
+	if s.publicURL != "" {
+		replacer := strings.NewReplacer("{bucket}", s.bucketName, "{key}", escapeObjectPath(objectName))
+		return replacer.Replace(s.publicURL)
+	}
+
+	host, path := s.objectLocation(objectName)
+	return fmt.Sprintf("%s://%s%s", s.endpoint.Scheme, host, path)
+}
+
+func (s *S3) UploadObject(ctx context.Context, objectName, contentType string, file io.Reader) error {
+	body, err := io.ReadAll(&contextReader{ctx: ctx, r: file})
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if contentType != "" {
+		headers["Content-Type"] = contentType
+	}
+
+	resp, err := s.do(ctx, "PUT", objectName, nil, body, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("upload failed: %s -- %s", resp.Status, body)
+	}
+
+	return nil
+}
+
+func (s *S3) GetObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, "GET", objectName, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get failed: %s -- %s", resp.Status, body)
+	}
+
+	return resp.Body, nil
+}
+
+func (s *S3) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	resp, err := s.do(ctx, "HEAD", objectName, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("stat failed: %s", resp.Status)
+	}
+
+	updatedAt, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &ObjectInfo{
+		Name:        objectName,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		UpdatedAt:   updatedAt,
+	}, nil
+}
+
+func (s *S3) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	return existsFromStat(s.StatObject(ctx, objectName))
+}
+
+func (s *S3) CopyObject(ctx context.Context, srcName, dstName string) error {
+	headers := map[string]string{
+		"X-Amz-Copy-Source": "/" + uriEncode(s.bucketName) + "/" + escapeObjectPath(srcName),
+	}
+
+	resp, err := s.do(ctx, "PUT", dstName, nil, nil, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 || bytes.Contains(body, []byte("<Error>")) {
+		return fmt.Errorf("copy failed: %s -- %s", resp.Status, body)
+	}
+
+	return nil
+}
+
+type s3ObjectPage struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+	continuationToken := ""
+
+	for {
+		query := map[string]string{"list-type": "2", "prefix": prefix}
+		if continuationToken != "" {
+			query["continuation-token"] = continuationToken
+		}
+
+		page, err := s.fetchObjectPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Name:      item.Key,
+				Size:      item.Size,
+				UpdatedAt: item.LastModified,
+			})
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		continuationToken = page.NextContinuationToken
+	}
+}
+
+func (s *S3) fetchObjectPage(ctx context.Context, query map[string]string) (*s3ObjectPage, error) {
+	resp, err := s.do(ctx, "GET", "", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list failed: %s -- %s", resp.Status, body)
+	}
+
+	var page s3ObjectPage
+	if err := xml.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (s *S3) SignedUploadURL(ctx context.Context, objectName, contentType string, expiresAt time.Time) (string, error) {
+	return s.presignedURL("PUT", objectName, contentType, expiresAt)
+}
+
+func (s *S3) SignedReadURL(ctx context.Context, objectName string, expiresAt time.Time) (string, error) {
+	return s.presignedURL("GET", objectName, "", expiresAt)
+}
+
+func (s *S3) presignedURL(method, objectName, contentType string, expiresAt time.Time) (string, error) {
+	now := time.Now()
+	host, path := s.objectLocation(objectName)
+
+	query, err := s.signer.presign(method, host, path, contentType, now, expiresAt.Sub(now))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://%s%s?%s", s.endpoint.Scheme, host, path, query), nil
+}
+
+func (s *S3) DeleteObject(ctx context.Context, objectName string) error {
+	resp, err := s.do(ctx, "DELETE", objectName, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete failed: %s -- %s", resp.Status, body)
+	}
+
+	return nil
+}
+
+func (s *S3) do(ctx context.Context, method, objectName string, query map[string]string, body []byte, headers map[string]string) (*http.Response, error) {
+	host, path := s.objectLocation(objectName)
+
+	rawURL := fmt.Sprintf("%s://%s%s", s.endpoint.Scheme, host, path)
+	if len(query) > 0 {
+		rawURL += "?" + canonicalQueryString(query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	sum := sha256.Sum256(body)
+	s.signer.signRequest(req, path, query, hex.EncodeToString(sum[:]), time.Now())
+	return http.DefaultClient.Do(req)
+}
+
+func (s *S3) objectLocation(objectName string) (string, string) {
+	key := escapeObjectPath(objectName)
+
+	if s.pathStyle {
+		path := "/" + uriEncode(s.bucketName)
+		if objectName != "" {
+			path += "/" + key
+		}
+		return s.endpoint.Host, path
+	}
+
+	return s.bucketName + "." + s.endpoint.Host, "/" + key
+}
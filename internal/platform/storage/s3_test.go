@@ -0,0 +1,129 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/art-vbst/art-backend/internal/platform/storage/storagetest"
+)
+
+// The S3 tests run against an S3-compatible endpoint, such as the MinIO
+// service in docker-compose.yml:
+//
+//	docker compose up -d minio minio-setup
+//	STORAGETEST_S3_ENDPOINT=http://localhost:9000 go test ./internal/platform/storage/
+//
+// The other settings default to the compose values.
+func TestS3(t *testing.T) {
+	storagetest.TestProvider(t, newTestS3(t))
+}
+
+func TestS3PresignedURLs(t *testing.T) {
+	provider := newTestS3(t)
+
+	ctx := context.Background()
+	name := fmt.Sprintf("storagetest/%d/presigned.jpg", time.Now().UnixNano())
+	data := []byte("presigned contents")
+	expiresAt := time.Now().Add(5 * time.Minute)
+	t.Cleanup(func() {
+		if err := provider.DeleteObject(ctx, name); err != nil {
+			t.Logf("cleanup: delete %s: %v", name, err)
+		}
+	})
+
+	uploadURL, err := provider.SignedUploadURL(ctx, name, "image/jpeg", expiresAt)
+	if err != nil {
+		t.Fatalf("SignedUploadURL: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, uploadURL, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("new PUT request: %v", err)
+	}
+	req.Header.Set("Content-Type", "image/jpeg")
+	if status, body := send(t, req); status != http.StatusOK {
+		t.Fatalf("PUT presigned URL: status %d: %s", status, body)
+	}
+
+	info, err := provider.StatObject(ctx, name)
+	if err != nil {
+		t.Fatalf("StatObject after presigned PUT: %v", err)
+	}
+	if info.Size != int64(len(data)) || info.ContentType != "image/jpeg" {
+		t.Fatalf("StatObject after presigned PUT = %d bytes %q, want %d bytes image/jpeg", info.Size, info.ContentType, len(data))
+	}
+
+	readURL, err := provider.SignedReadURL(ctx, name, expiresAt)
+	if err != nil {
+		t.Fatalf("SignedReadURL: %v", err)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, readURL, nil)
+	if err != nil {
+		t.Fatalf("new GET request: %v", err)
+	}
+	status, body := send(t, req)
+	if status != http.StatusOK {
+		t.Fatalf("GET presigned URL: status %d: %s", status, body)
+	}
+	if !bytes.Equal(body, data) {
+		t.Fatalf("GET presigned URL = %q, want %q", body, data)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, provider.GetObjectURL(name), nil)
+	if err != nil {
+		t.Fatalf("new unsigned GET request: %v", err)
+	}
+	if status, _ := send(t, req); status == http.StatusOK {
+		t.Fatalf("unsigned GET of a private object succeeded")
+	}
+}
+
+func send(t *testing.T, req *http.Request) (int, []byte) {
+	t.Helper()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read %s response: %v", req.Method, err)
+	}
+	return resp.StatusCode, body
+}
+
+func newTestS3(t *testing.T) *storage.S3 {
+	t.Helper()
+
+	endpoint := os.Getenv("STORAGETEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("STORAGETEST_S3_ENDPOINT not set")
+	}
+
+	return storage.NewS3(&config.Config{
+		S3Endpoint:        endpoint,
+		S3BucketName:      envOr("STORAGETEST_S3_BUCKET", "art"),
+		S3Region:          envOr("STORAGETEST_S3_REGION", "us-east-1"),
+		S3AccessKeyID:     envOr("STORAGETEST_S3_ACCESS_KEY_ID", "minioadmin"),
+		S3SecretAccessKey: envOr("STORAGETEST_S3_SECRET_ACCESS_KEY", "minioadmin"),
+		S3PathStyle:       os.Getenv("STORAGETEST_S3_PATH_STYLE") != "false",
+	})
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -1,33 +1,93 @@
 package storage
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/art-vbst/art-backend/internal/platform/config"
 )
 
+const PrivateObjectPrefix = "private/"
+
+var (
+	ErrObjectNotFound    = errors.New("object not found")
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrInvalidObjectName = errors.New("invalid object name")
+)
+
 type UploadFileData struct {
 	File        multipart.File
 	FileName    string
 	ContentType string
 }
 
+type ObjectInfo struct {
+	Name        string
+	Size        int64
+	ContentType string
+	UpdatedAt   time.Time
+}
+
 type Provider interface {
 	Close()
 	GetObjectName(fileName string) string
 	GetObjectURL(objectName string) string
-	UploadObject(objectName, contentType string, file io.Reader) error
-	DeleteObject(objectName string) error
+	UploadObject(ctx context.Context, objectName, contentType string, file io.Reader) error
+	GetObject(ctx context.Context, objectName string) (io.ReadCloser, error)
+	StatObject(ctx context.Context, objectName string) (*ObjectInfo, error)
+	ObjectExists(ctx context.Context, objectName string) (bool, error)
+	ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	CopyObject(ctx context.Context, srcName, dstName string) error
+	SignedUploadURL(ctx context.Context, objectName, contentType string, expiresAt time.Time) (string, error)
+	SignedReadURL(ctx context.Context, objectName string, expiresAt time.Time) (string, error)
+	DeleteObject(ctx context.Context, objectName string) error
 }
 
 func NewProvider(env *config.Config) Provider {
 	switch {
 	case config.IsDebug() && env.LocalStorageDir != "":
 		baseUrl := fmt.Sprintf("http://localhost:%s", env.Port)
-		return NewLocalStorage(baseUrl, env.LocalStorageDir)
+		return NewLocalStorage(baseUrl, env.LocalStorageDir, env.JwtSecret)
+	case env.StorageProvider == "s3":
+		return NewS3(env)
 	default:
 		return NewGCS(env.GCSBucketName)
 	}
 }
+
+func ContentObjectName(content []byte, fileName string) string {
+	sum := sha256.Sum256(content)
+	ext := strings.ToLower(path.Ext(sanitizeFileName(path.Base(strings.TrimSpace(fileName)))))
+	return "uploads/" + hex.EncodeToString(sum[:]) + ext
+}
+
+func IsPrivateObject(objectName string) bool {
+	return strings.HasPrefix(objectName, PrivateObjectPrefix)
+}
+
+func existsFromStat(_ *ObjectInfo, err error) (bool, error) {
+	if errors.Is(err, ErrObjectNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,231 @@
+// Package storagetest provides a conformance suite for storage.Provider
+// implementations.
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+)
+
+// TestProvider exercises the behaviour every storage.Provider must share.
+// Objects are written under a unique prefix and removed when the test ends,
+// so it is safe to run against a real bucket.
+func TestProvider(t *testing.T, provider storage.Provider) {
+	t.Helper()
+
+	ctx := context.Background()
+	prefix := fmt.Sprintf("storagetest/%d/", time.Now().UnixNano())
+	t.Cleanup(func() {
+		objects, err := provider.ListObjects(ctx, prefix)
+		if err != nil {
+			t.Logf("cleanup: list %s: %v", prefix, err)
+			return
+		}
+		for _, object := range objects {
+			if err := provider.DeleteObject(ctx, object.Name); err != nil {
+				t.Logf("cleanup: delete %s: %v", object.Name, err)
+			}
+		}
+	})
+
+	upload := func(t *testing.T, name string, data []byte) {
+		t.Helper()
+		if err := provider.UploadObject(ctx, name, "image/jpeg", bytes.NewReader(data)); err != nil {
+			t.Fatalf("UploadObject(%q): %v", name, err)
+		}
+	}
+
+	t.Run("UploadAndGet", func(t *testing.T) {
+		name := prefix + "round-trip.jpg"
+		data := []byte("round trip contents")
+		upload(t, name, data)
+
+		if got := read(t, provider, name); !bytes.Equal(got, data) {
+			t.Fatalf("GetObject(%q) = %q, want %q", name, got, data)
+		}
+
+		replacement := []byte("replaced")
+		upload(t, name, replacement)
+		if got := read(t, provider, name); !bytes.Equal(got, replacement) {
+			t.Fatalf("GetObject(%q) after overwrite = %q, want %q", name, got, replacement)
+		}
+	})
+
+	t.Run("Stat", func(t *testing.T) {
+		name := prefix + "stat.jpg"
+		data := []byte("stat contents")
+		upload(t, name, data)
+
+		info, err := provider.StatObject(ctx, name)
+		if err != nil {
+			t.Fatalf("StatObject(%q): %v", name, err)
+		}
+		if info.Name != name {
+			t.Errorf("StatObject(%q).Name = %q", name, info.Name)
+		}
+		if info.Size != int64(len(data)) {
+			t.Errorf("StatObject(%q).Size = %d, want %d", name, info.Size, len(data))
+		}
+		if info.ContentType != "image/jpeg" {
+			t.Errorf("StatObject(%q).ContentType = %q, want image/jpeg", name, info.ContentType)
+		}
+		if info.UpdatedAt.IsZero() {
+			t.Errorf("StatObject(%q).UpdatedAt is zero", name)
+		}
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		name := prefix + "exists.jpg"
+		upload(t, name, []byte("exists"))
+
+		if ok, err := provider.ObjectExists(ctx, name); err != nil || !ok {
+			t.Fatalf("ObjectExists(%q) = %v, %v, want true, nil", name, ok, err)
+		}
+		if ok, err := provider.ObjectExists(ctx, prefix+"missing.jpg"); err != nil || ok {
+			t.Fatalf("ObjectExists(missing) = %v, %v, want false, nil", ok, err)
+		}
+	})
+
+	t.Run("MissingObject", func(t *testing.T) {
+		name := prefix + "missing.jpg"
+
+		if _, err := provider.GetObject(ctx, name); !errors.Is(err, storage.ErrObjectNotFound) {
+			t.Errorf("GetObject(missing) error = %v, want ErrObjectNotFound", err)
+		}
+		if _, err := provider.StatObject(ctx, name); !errors.Is(err, storage.ErrObjectNotFound) {
+			t.Errorf("StatObject(missing) error = %v, want ErrObjectNotFound", err)
+		}
+		if err := provider.CopyObject(ctx, name, prefix+"copy-of-missing.jpg"); !errors.Is(err, storage.ErrObjectNotFound) {
+			t.Errorf("CopyObject(missing) error = %v, want ErrObjectNotFound", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		listPrefix := prefix + "list/"
+		names := []string{listPrefix + "a.jpg", listPrefix + "b.jpg", listPrefix + "nested/c.jpg"}
+		for _, name := range names {
+			upload(t, name, []byte(name))
+		}
+		upload(t, prefix+"list-sibling.jpg", []byte("sibling"))
+
+		objects, err := provider.ListObjects(ctx, listPrefix)
+		if err != nil {
+			t.Fatalf("ListObjects(%q): %v", listPrefix, err)
+		}
+
+		got := []string{}
+		for _, object := range objects {
+			got = append(got, object.Name)
+			if object.Size != int64(len(object.Name)) {
+				t.Errorf("ListObjects size for %q = %d, want %d", object.Name, object.Size, len(object.Name))
+			}
+		}
+		sort.Strings(got)
+		if fmt.Sprint(got) != fmt.Sprint(names) {
+			t.Fatalf("ListObjects(%q) = %v, want %v", listPrefix, got, names)
+		}
+
+		empty, err := provider.ListObjects(ctx, prefix+"nothing-here/")
+		if err != nil {
+			t.Fatalf("ListObjects(empty prefix): %v", err)
+		}
+		if len(empty) != 0 {
+			t.Fatalf("ListObjects(empty prefix) = %v, want none", empty)
+		}
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		src := prefix + "copy-src.jpg"
+		dst := prefix + "copy/dst.jpg"
+		data := []byte("copy contents")
+		upload(t, src, data)
+
+		if err := provider.CopyObject(ctx, src, dst); err != nil {
+			t.Fatalf("CopyObject(%q, %q): %v", src, dst, err)
+		}
+		if got := read(t, provider, dst); !bytes.Equal(got, data) {
+			t.Fatalf("GetObject(%q) = %q, want %q", dst, got, data)
+		}
+		if got := read(t, provider, src); !bytes.Equal(got, data) {
+			t.Fatalf("CopyObject modified source: %q", got)
+		}
+	})
+
+	t.Run("ObjectName", func(t *testing.T) {
+		name := provider.GetObjectName("../My Painting.JPG")
+		if !strings.HasPrefix(name, "uploads/") || strings.Contains(name, "..") {
+			t.Errorf("GetObjectName = %q, want a name under uploads/", name)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		name := prefix + "delete.jpg"
+		upload(t, name, []byte("delete me"))
+
+		if err := provider.DeleteObject(ctx, name); err != nil {
+			t.Fatalf("DeleteObject(%q): %v", name, err)
+		}
+		if ok, err := provider.ObjectExists(ctx, name); err != nil || ok {
+			t.Fatalf("ObjectExists after delete = %v, %v, want false, nil", ok, err)
+		}
+		if err := provider.DeleteObject(ctx, name); err != nil {
+			t.Fatalf("DeleteObject(%q) twice: %v", name, err)
+		}
+	})
+
+	t.Run("SignedURLs", func(t *testing.T) {
+		name := prefix + "signed.jpg"
+		expiresAt := time.Now().Add(10 * time.Minute)
+
+		if url, err := provider.SignedUploadURL(ctx, name, "image/jpeg", expiresAt); err != nil || url == "" {
+			t.Errorf("SignedUploadURL(%q) = %q, %v", name, url, err)
+		}
+		if url, err := provider.SignedReadURL(ctx, name, expiresAt); err != nil || url == "" {
+			t.Errorf("SignedReadURL(%q) = %q, %v", name, url, err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		name := prefix + "canceled.jpg"
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		if err := provider.UploadObject(canceled, name, "image/jpeg", bytes.NewReader([]byte("x"))); err == nil {
+			t.Errorf("UploadObject with canceled context succeeded")
+		}
+		if _, err := provider.GetObject(canceled, name); !errors.Is(err, context.Canceled) {
+			t.Errorf("GetObject with canceled context error = %v, want context.Canceled", err)
+		}
+		if _, err := provider.ListObjects(canceled, prefix); !errors.Is(err, context.Canceled) {
+			t.Errorf("ListObjects with canceled context error = %v, want context.Canceled", err)
+		}
+		if ok, _ := provider.ObjectExists(ctx, name); ok {
+			t.Errorf("UploadObject with canceled context left %q behind", name)
+		}
+	})
+}
+
+func read(t *testing.T, provider storage.Provider, name string) []byte {
+	t.Helper()
+
+	reader, err := provider.GetObject(context.Background(), name)
+	if err != nil {
+		t.Fatalf("GetObject(%q): %v", name, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read %q: %v", name, err)
+	}
+	return data
+}
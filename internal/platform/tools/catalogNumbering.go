@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+)
+
+var (
+	ErrInvalidNumberingScheme = errors.New("-scheme must be one of global, year, medium")
+)
+
+// CatalogNumbering switches the painting number unique index to another
+// scheme. Run it before changing CATALOG_NUMBERING; the server refuses to
+// start while the two disagree.
+func CatalogNumbering(ctx context.Context, store *store.Store, args []string) error {
+	flags := flag.NewFlagSet("catalognumbering", flag.ContinueOnError)
+	schemeFlag := flags.String("scheme", "", "numbering scheme to enforce: global, year or medium")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	scheme := domain.NumberingScheme(*schemeFlag)
+	valid := []domain.NumberingScheme{domain.NumberingSchemeGlobal, domain.NumberingSchemeYear, domain.NumberingSchemeMedium}
+	if !slices.Contains(valid, scheme) {
+		return ErrInvalidNumberingScheme
+	}
+
+	artRepo := repo.New(store)
+
+	current, err := artRepo.GetCatalogNumbering(ctx)
+	if err != nil {
+		return err
+	}
+	if current == scheme {
+		fmt.Printf("Database already enforces %s numbering\n", scheme)
+		return nil
+	}
+
+	if err := artRepo.SwitchCatalogNumbering(ctx, scheme); err != nil {
+		return fmt.Errorf("switch to %s numbering, run catalogreport to find duplicates: %w", scheme, err)
+	}
+
+	fmt.Printf("Database now enforces %s numbering, set CATALOG_NUMBERING=%s\n", scheme, scheme)
+	return nil
+}
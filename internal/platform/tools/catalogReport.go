@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+)
+
+func CatalogReport(ctx context.Context, store *store.Store, env *config.Config) error {
+	artRepo := repo.New(store)
+
+	entries, err := artRepo.ListCatalogEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := domain.BuildCatalogReport(entries, domain.NumberingScheme(env.CatalogNumbering))
+	printCatalogReport(report)
+	return nil
+}
+
+func printCatalogReport(report *domain.CatalogReport) {
+	fmt.Printf("Numbering scheme: %s\n", report.Scheme)
+
+	for _, scope := range report.Scopes {
+		fmt.Println()
+		fmt.Printf("[%s] %d numbered, highest #%d\n", scope.Scope, scope.Numbered, scope.Highest)
+
+		for _, duplicate := range scope.Duplicates {
+			titles := make([]string, len(duplicate.Entries))
+			for i, entry := range duplicate.Entries {
+				titles[i] = fmt.Sprintf("%q (%s)", entry.Title, entry.ID)
+			}
+			fmt.Printf("  duplicate #%d: %s\n", duplicate.PaintingNumber, strings.Join(titles, ", "))
+		}
+
+		for _, gap := range scope.Gaps {
+			if gap.From == gap.To {
+				fmt.Printf("  gap: #%d\n", gap.From)
+				continue
+			}
+			fmt.Printf("  gap: #%d-#%d\n", gap.From, gap.To)
+		}
+
+		for _, entry := range scope.Unnumbered {
+			fmt.Printf("  unnumbered: %q (%s)\n", entry.Title, entry.ID)
+		}
+	}
+}
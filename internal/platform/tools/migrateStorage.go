@@ -0,0 +1,303 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+)
+
+var (
+	ErrInvalidTarget    = errors.New("-to must be one of gcs, s3 or local")
+	ErrMissingTarget    = errors.New("target storage is not fully configured")
+	ErrSameTarget       = errors.New("source and target storage are the same")
+	ErrChecksumMismatch = errors.New("checksum mismatch after copy")
+	ErrImageChanged     = errors.New("image files changed during migration")
+)
+
+type storageMigration struct {
+	repo     repo.Repo
+	source   storage.Provider
+	target   storage.Provider
+	targetID string
+	migrated map[string]string
+	dryRun   bool
+}
+
+type migrationResult struct {
+	image     domain.Image
+	copied    int
+	skipped   int
+	rewritten bool
+	err       error
+}
+
+func MigrateStorage(ctx context.Context, store *store.Store, source storage.Provider, env *config.Config, args []string) error {
+	flags := flag.NewFlagSet("migratestorage", flag.ContinueOnError)
+	to := flags.String("to", "", "target provider: gcs, s3 or local")
+	bucket := flags.String("bucket", "", "target bucket for gcs or s3")
+	endpoint := flags.String("endpoint", env.S3Endpoint, "target s3 endpoint")
+	region := flags.String("region", env.S3Region, "target s3 region")
+	pathStyle := flags.Bool("path-style", env.S3PathStyle, "use path-style s3 urls")
+	publicURL := flags.String("public-url", "", "target s3 public url template")
+	dir := flags.String("dir", "", "target directory for local storage")
+	baseURL := flags.String("base-url", "http://localhost:"+env.Port, "target base url for local storage")
+	concurrency := flags.Int("concurrency", 4, "number of images to migrate in parallel")
+	dryRun := flags.Bool("dry-run", false, "report what would be copied without writing anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	targetEnv := *env
+	targetEnv.S3BucketName = *bucket
+	targetEnv.S3Endpoint = *endpoint
+	targetEnv.S3Region = *region
+	targetEnv.S3PathStyle = *pathStyle
+	targetEnv.S3PublicURL = *publicURL
+
+	var target storage.Provider
+	switch *to {
+	case "gcs":
+		if *bucket == "" {
+			return fmt.Errorf("%w: -bucket is required", ErrMissingTarget)
+		}
+		target = storage.NewGCS(*bucket)
+	case "s3":
+		if *bucket == "" || env.S3AccessKeyID == "" || env.S3SecretAccessKey == "" {
+			return fmt.Errorf("%w: -bucket, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required", ErrMissingTarget)
+		}
+		target = storage.NewS3(&targetEnv)
+	case "local":
+		if *dir == "" {
+			return fmt.Errorf("%w: -dir is required", ErrMissingTarget)
+		}
+		target = storage.NewLocalStorage(*baseURL, *dir, env.JwtSecret)
+	default:
+		return ErrInvalidTarget
+	}
+	defer target.Close()
+
+	targetID := storageID(*to, &targetEnv, *bucket, *dir)
+	sourceID := storageID(env.StorageProvider, env, env.GCSBucketName, "")
+	if config.IsDebug() && env.LocalStorageDir != "" {
+		sourceID = storageID("local", env, "", env.LocalStorageDir)
+	}
+	if targetID == sourceID {
+		return ErrSameTarget
+	}
+
+	artRepo := repo.New(store)
+
+	images, err := artRepo.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrated, err := artRepo.ListMigratedObjects(ctx, targetID)
+	if err != nil {
+		return err
+	}
+
+	m := &storageMigration{
+		repo:     artRepo,
+		source:   source,
+		target:   target,
+		targetID: targetID,
+		migrated: migrated,
+		dryRun:   *dryRun,
+	}
+
+	fmt.Printf("Migrating %d images from %s to %s (%d objects already copied)\n", len(images), sourceID, targetID, len(migrated))
+	if *dryRun {
+		fmt.Println("Dry run: nothing will be copied or updated")
+	}
+	fmt.Println()
+
+	copied, skipped, rewritten, failed := 0, 0, 0, 0
+	for result := range m.run(ctx, images, max(1, *concurrency)) {
+		if result.err != nil {
+			failed++
+			fmt.Printf("failed %s (%s): %v\n", result.image.ID, result.image.ObjectName, result.err)
+			continue
+		}
+
+		copied += result.copied
+		skipped += result.skipped
+		if result.rewritten {
+			rewritten++
+		}
+		if result.copied > 0 || result.rewritten {
+			fmt.Printf("migrated %s: %d objects copied, %d already present\n", result.image.ID, result.copied, result.skipped)
+		}
+	}
+
+	fmt.Println()
+	if *dryRun {
+		fmt.Printf("Would copy %d objects (%d already copied) and rewrite %d image urls, %d failed\n", copied, skipped, rewritten, failed)
+	} else {
+		fmt.Printf("Copied %d objects (%d already copied) and rewrote %d image urls, %d failed\n", copied, skipped, rewritten, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d images failed to migrate; rerun to resume", failed)
+	}
+	return nil
+}
+
+func (m *storageMigration) run(ctx context.Context, images []domain.Image, concurrency int) <-chan migrationResult {
+	jobs := make(chan domain.Image)
+	results := make(chan migrationResult)
+
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Go(func() {
+			for image := range jobs {
+				results <- m.migrateImage(ctx, image)
+			}
+		})
+	}
+
+	go func() {
+		for _, image := range images {
+			jobs <- image
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func (m *storageMigration) migrateImage(ctx context.Context, image domain.Image) migrationResult {
+	result := migrationResult{image: image}
+
+	names := imageObjectNames(&image)
+	for _, name := range names {
+		if _, ok := m.migrated[name]; ok {
+			result.skipped++
+			continue
+		}
+		if err := m.copyObject(ctx, name); err != nil {
+			result.err = fmt.Errorf("copy %s: %w", name, err)
+			return result
+		}
+		result.copied++
+	}
+
+	_, _, result.rewritten = m.targetURLs(&image)
+
+	if result.rewritten && !m.dryRun {
+		// The urls are rewritten from the row as it is now, and only if it
+		// still points at the objects copied above.
+		_, err := m.repo.UpdateImageURLs(ctx, image.ID, func(current *domain.Image) (string, []domain.ImageVariant, error) {
+			if !slices.Equal(imageObjectNames(current), names) {
+				return "", nil, ErrImageChanged
+			}
+			imageURL, variants, _ := m.targetURLs(current)
+			return imageURL, variants, nil
+		})
+		if err != nil {
+			result.err = fmt.Errorf("update urls: %w", err)
+		}
+	}
+
+	return result
+}
+
+func (m *storageMigration) targetURLs(image *domain.Image) (string, []domain.ImageVariant, bool) {
+	imageURL := m.target.GetObjectURL(image.ObjectName)
+	rewritten := imageURL != image.ImageURL
+
+	variants := slices.Clone(image.Variants)
+	for i := range variants {
+		if url := m.target.GetObjectURL(variants[i].ObjectName); url != variants[i].URL {
+			variants[i].URL = url
+			rewritten = true
+		}
+	}
+
+	return imageURL, variants, rewritten
+}
+
+func imageObjectNames(image *domain.Image) []string {
+	names := []string{image.ObjectName}
+	for _, variant := range image.Variants {
+		names = append(names, variant.ObjectName)
+	}
+	return names
+}
+
+func (m *storageMigration) copyObject(ctx context.Context, name string) error {
+	info, err := m.source.StatObject(ctx, name)
+	if err != nil {
+		return err
+	}
+	if m.dryRun {
+		return nil
+	}
+
+	rc, err := m.source.GetObject(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if err := m.target.UploadObject(ctx, name, info.ContentType, io.TeeReader(rc, hash)); err != nil {
+		return err
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	copied, size, err := objectChecksum(ctx, m.target, name)
+	if err != nil {
+		return err
+	}
+	if copied != checksum || size != info.Size {
+		return fmt.Errorf("%w: source %s (%d bytes), target %s (%d bytes)", ErrChecksumMismatch, checksum, info.Size, copied, size)
+	}
+
+	return m.repo.RecordMigratedObject(ctx, m.targetID, name, checksum, size)
+}
+
+func objectChecksum(ctx context.Context, provider storage.Provider, name string) (string, int64, error) {
+	rc, err := provider.GetObject(ctx, name)
+	if err != nil {
+		return "", 0, err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, rc)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+func storageID(provider string, env *config.Config, bucket, dir string) string {
+	switch provider {
+	case "s3":
+		return fmt.Sprintf("s3:%s/%s", env.S3Endpoint, env.S3BucketName)
+	case "local":
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		return "local:" + dir
+	default:
+		return "gcs:" + bucket
+	}
+}
@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/art-vbst/art-backend/internal/artwork/domain"
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/artwork/service"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	quarantinePrefix = "quarantine/"
+	incomingPrefix   = "incoming/"
+)
+
+var reconcilePrefixes = []string{"uploads/", incomingPrefix, storage.PrivateObjectPrefix}
+
+var (
+	ErrConflictingFlags = errors.New("-delete and -quarantine cannot be combined")
+)
+
+type brokenReference struct {
+	image      domain.Image
+	objectName string
+	variant    string
+}
+
+func ReconcileStorage(ctx context.Context, store *store.Store, provider storage.Provider, args []string) error {
+	flags := flag.NewFlagSet("reconcilestorage", flag.ContinueOnError)
+	deleteOrphans := flags.Bool("delete", false, "delete orphaned objects")
+	quarantine := flags.Bool("quarantine", false, "move orphaned objects under "+quarantinePrefix)
+	pruneBroken := flags.Bool("prune-broken", false, "delete image rows whose original object is missing")
+	minAge := flags.Duration("min-age", service.DirectUploadLifetime, "ignore objects modified more recently than this")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *deleteOrphans && *quarantine {
+		return ErrConflictingFlags
+	}
+
+	artRepo := repo.New(store)
+
+	images, err := artRepo.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	objects := []storage.ObjectInfo{}
+	for _, prefix := range reconcilePrefixes {
+		listed, err := provider.ListObjects(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, listed...)
+	}
+
+	// Pending direct uploads stay finalizable for DirectUploadLifetime, so
+	// they are never treated as orphans before then, whatever -min-age says.
+	now := time.Now()
+	orphans, broken := diffStorage(images, objects, now.Add(-*minAge), now.Add(-max(*minAge, service.DirectUploadLifetime)))
+	printReconcileReport(len(images), len(objects), orphans, broken)
+
+	switch {
+	case *deleteOrphans:
+		for _, object := range orphans {
+			if err := provider.DeleteObject(ctx, object.Name); err != nil {
+				return fmt.Errorf("delete %s: %w", object.Name, err)
+			}
+			fmt.Printf("deleted %s\n", object.Name)
+		}
+	case *quarantine:
+		for _, object := range orphans {
+			if err := quarantineObject(ctx, provider, object); err != nil {
+				return fmt.Errorf("quarantine %s: %w", object.Name, err)
+			}
+			fmt.Printf("quarantined %s\n", object.Name)
+		}
+	}
+
+	if *pruneBroken {
+		pruned := map[uuid.UUID]bool{}
+		for _, ref := range broken {
+			if ref.variant != "" || pruned[ref.image.ID] {
+				continue
+			}
+			released, err := artRepo.DeleteImage(ctx, ref.image.ID)
+			if err != nil {
+				return fmt.Errorf("delete image %s: %w", ref.image.ID, err)
+			}
+			if released != nil {
+				err := artRepo.DeleteReleasedObject(ctx, released.ObjectName, func() {
+					for _, variant := range released.Variants {
+						if err := provider.DeleteObject(ctx, variant.ObjectName); err != nil {
+							fmt.Printf("warning: failed to delete %s: %v\n", variant.ObjectName, err)
+						}
+					}
+				})
+				if err != nil {
+					fmt.Printf("warning: failed to delete variants of %s: %v\n", ref.image.ID, err)
+				}
+			}
+			pruned[ref.image.ID] = true
+			fmt.Printf("deleted image row %s\n", ref.image.ID)
+		}
+	}
+
+	return nil
+}
+
+func diffStorage(images []domain.Image, objects []storage.ObjectInfo, cutoff, incomingCutoff time.Time) ([]storage.ObjectInfo, []brokenReference) {
+	referenced := map[string]bool{}
+	for _, image := range images {
+		referenced[image.ObjectName] = true
+		for _, variant := range image.Variants {
+			referenced[variant.ObjectName] = true
+		}
+	}
+
+	existing := map[string]bool{}
+	orphans := []storage.ObjectInfo{}
+	for _, object := range objects {
+		existing[object.Name] = true
+
+		objectCutoff := cutoff
+		if strings.HasPrefix(object.Name, incomingPrefix) {
+			objectCutoff = incomingCutoff
+		}
+		if !referenced[object.Name] && object.UpdatedAt.Before(objectCutoff) {
+			orphans = append(orphans, object)
+		}
+	}
+
+	broken := []brokenReference{}
+	for _, image := range images {
+		if !existing[image.ObjectName] {
+			broken = append(broken, brokenReference{image: image, objectName: image.ObjectName})
+		}
+		for _, variant := range image.Variants {
+			if !existing[variant.ObjectName] {
+				broken = append(broken, brokenReference{image: image, objectName: variant.ObjectName, variant: variant.Name})
+			}
+		}
+	}
+
+	return orphans, broken
+}
+
+func quarantineObject(ctx context.Context, provider storage.Provider, object storage.ObjectInfo) error {
+	if err := provider.CopyObject(ctx, object.Name, quarantinePrefix+object.Name); err != nil {
+		return err
+	}
+
+	return provider.DeleteObject(ctx, object.Name)
+}
+
+func printReconcileReport(imageCount, objectCount int, orphans []storage.ObjectInfo, broken []brokenReference) {
+	fmt.Printf("Scanned %d image rows and %d stored objects\n", imageCount, objectCount)
+
+	fmt.Println()
+	fmt.Printf("Orphaned objects: %d\n", len(orphans))
+	for _, object := range orphans {
+		fmt.Printf("  %s (%d bytes, %s)\n", object.Name, object.Size, object.UpdatedAt.Format(time.RFC3339))
+	}
+
+	fmt.Println()
+	fmt.Printf("Broken references: %d\n", len(broken))
+	for _, ref := range broken {
+		kind := "original"
+		if ref.variant != "" {
+			kind = ref.variant + " variant"
+		}
+		fmt.Printf("  image %s (artwork %s): missing %s %s\n", ref.image.ID, ref.image.ArtworkID, kind, ref.objectName)
+	}
+}
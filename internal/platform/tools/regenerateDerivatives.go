@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/art-vbst/art-backend/internal/artwork/repo"
+	"github.com/art-vbst/art-backend/internal/artwork/service"
+	"github.com/art-vbst/art-backend/internal/platform/config"
+	"github.com/art-vbst/art-backend/internal/platform/db/store"
+	"github.com/art-vbst/art-backend/internal/platform/storage"
+	"github.com/google/uuid"
+)
+
+func RegenerateDerivatives(ctx context.Context, store *store.Store, provider storage.Provider, env *config.Config, args []string) error {
+	flags := flag.NewFlagSet("regeneratederivatives", flag.ContinueOnError)
+	artworkID := flags.String("artwork", "", "only regenerate images for this artwork id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var filter *uuid.UUID
+	if *artworkID != "" {
+		id, err := uuid.Parse(*artworkID)
+		if err != nil {
+			return err
+		}
+		filter = &id
+	}
+
+	artRepo := repo.New(store)
+	imageService, err := service.NewImageService(artRepo, provider, env)
+	if err != nil {
+		return err
+	}
+
+	images, err := artRepo.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	regenerated, failed := 0, 0
+	for _, image := range images {
+		if filter != nil && image.ArtworkID != *filter {
+			continue
+		}
+
+		updated, err := imageService.RegenerateVariants(ctx, &image)
+		if err != nil {
+			failed++
+			fmt.Printf("failed %s (%s): %v\n", image.ID, image.ObjectName, err)
+			continue
+		}
+
+		regenerated++
+		fmt.Printf("regenerated %s: %d variants\n", image.ID, len(updated.Variants))
+	}
+
+	fmt.Println()
+	fmt.Printf("Regenerated %d images, %d failed\n", regenerated, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d images failed to regenerate", failed)
+	}
+	return nil
+}
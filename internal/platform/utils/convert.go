@@ -2,6 +2,7 @@ package utils
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -14,3 +15,31 @@ func NumericFromFloat(f float64) (pgtype.Numeric, error) {
 	}
 	return n, nil
 }
+
+func NumericFromFloatPtr(f *float64) (pgtype.Numeric, error) {
+	if f == nil {
+		return pgtype.Numeric{}, nil
+	}
+	return NumericFromFloat(*f)
+}
+
+func FloatPtrFromNumeric(n pgtype.Numeric) (*float64, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	f, err := n.Float64Value()
+	if err != nil {
+		return nil, err
+	}
+
+	return &f.Float64, nil
+}
+
+func NilIfEmpty(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
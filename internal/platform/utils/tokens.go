@@ -14,6 +14,8 @@ const (
 	TOTPTokenType     = "totp"
 	AccessTokenType   = "access"
 	RefreshTokenType  = "refresh"
+	PreviewTokenType  = "preview"
+	UploadTokenType   = "upload"
 	TOTPExpiration    = 2 * time.Minute
 	AccessExpiration  = 5 * time.Minute
 	RefreshExpiration = 14 * 24 * time.Hour
@@ -45,6 +47,21 @@ type RefreshClaims struct {
 	jwt.RegisteredClaims
 }
 
+type PreviewClaims struct {
+	TokenType string    `json:"typ"`
+	LinkID    uuid.UUID `json:"lid"`
+	jwt.RegisteredClaims
+}
+
+type UploadClaims struct {
+	TokenType   string    `json:"typ"`
+	ArtworkID   uuid.UUID `json:"aid"`
+	ObjectName  string    `json:"obj"`
+	FileName    string    `json:"fn"`
+	ContentType string    `json:"ct"`
+	jwt.RegisteredClaims
+}
+
 func CreateTOTPToken(user *domain.User, secret string) (string, error) {
 	byteSecret := []byte(secret)
 
@@ -96,6 +113,35 @@ func CreateRefreshToken(userID uuid.UUID, existingExpiresAt *time.Time, secret s
 	return tokenString, &claims, nil
 }
 
+func CreatePreviewToken(linkID uuid.UUID, expiresAt time.Time, secret string) (string, error) {
+	byteSecret := []byte(secret)
+
+	claims := PreviewClaims{
+		TokenType:        PreviewTokenType,
+		LinkID:           linkID,
+		RegisteredClaims: getRegisteredClaims(linkID, expiresAt),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
+	return token.SignedString(byteSecret)
+}
+
+func CreateUploadToken(artworkID uuid.UUID, objectName, fileName, contentType string, expiresAt time.Time, secret string) (string, error) {
+	byteSecret := []byte(secret)
+
+	claims := UploadClaims{
+		TokenType:        UploadTokenType,
+		ArtworkID:        artworkID,
+		ObjectName:       objectName,
+		FileName:         fileName,
+		ContentType:      contentType,
+		RegisteredClaims: getRegisteredClaims(artworkID, expiresAt),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
+	return token.SignedString(byteSecret)
+}
+
 func getRegisteredClaims(userID uuid.UUID, expiresAt time.Time) jwt.RegisteredClaims {
 	return jwt.RegisteredClaims{
 		Subject:   userID.String(),
@@ -140,6 +186,28 @@ func ParseRefreshToken(tokenStr string, secret string) (*RefreshClaims, error) {
 	return claims, nil
 }
 
+func ParsePreviewToken(tokenStr string, secret string) (*PreviewClaims, error) {
+	claims := &PreviewClaims{}
+	if err := parseTokenWithClaims(tokenStr, secret, claims); err != nil {
+		return nil, err
+	}
+	if claims.TokenType != PreviewTokenType {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func ParseUploadToken(tokenStr string, secret string) (*UploadClaims, error) {
+	claims := &UploadClaims{}
+	if err := parseTokenWithClaims(tokenStr, secret, claims); err != nil {
+		return nil, err
+	}
+	if claims.TokenType != UploadTokenType {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
 func parseTokenWithClaims(tokenStr, secret string, claims jwt.Claims) error {
 	keyFunc := func(t *jwt.Token) (any, error) {
 		if t.Method.Alg() != jwt.SigningMethodHS512.Alg() {